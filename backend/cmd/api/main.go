@@ -2,9 +2,11 @@ package main
 
 import (
 	"Reservify/config"
+	"Reservify/jobs"
 	"Reservify/models"
 	"Reservify/routes"
 	"log"
+	_ "time/tzdata" // Zonas IANA embebidas para no depender del sistema
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +21,10 @@ func main() {
 	if err := models.AutoMigrate(config.GetDB()); err != nil {
 		log.Fatal(" Error en migraciones:", err)
 	}
+
+	// Tareas programadas (expiración y cierre de reservas)
+	jobs.Start(config.GetDB())
+
 	// Configurar modo de Gin
 	if config.AppConfig.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -16,7 +16,8 @@ var DB *gorm.DB
 func ConnectDatabase() {
 	var err error
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	// Todas las fechas se guardan en UTC; la zona local de cada recurso se aplica en los servicios
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
 		AppConfig.DBUser,
 		AppConfig.DBPassword,
 		AppConfig.DBHost,
@@ -27,7 +28,7 @@ func ConnectDatabase() {
 	config := &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 		NowFunc: func() time.Time {
-			return time.Now().Local()
+			return time.Now().UTC()
 		},
 	}
 
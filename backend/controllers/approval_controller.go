@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApprovalController struct {
+	bookingService *services.BookingService
+}
+
+func NewApprovalController(bookingService *services.BookingService) *ApprovalController {
+	return &ApprovalController{bookingService: bookingService}
+}
+
+// GetApprovalQueue obtiene las reservas pendientes que el usuario autenticado puede aprobar
+// GET /api/approvals
+func (ctrl *ApprovalController) GetApprovalQueue(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	bookings, err := ctrl.bookingService.GetApprovalQueue(userID.(uint), isAdmin)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener las aprobaciones pendientes", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Aprobaciones pendientes obtenidas exitosamente", bookings)
+}
+
+// ApproveBooking aprueba una reserva pendiente
+// POST /api/approvals/:id/approve
+func (ctrl *ApprovalController) ApproveBooking(c *gin.Context) {
+	ctrl.decide(c, ctrl.bookingService.ApproveBooking, "Reserva aprobada exitosamente")
+}
+
+// RejectBooking rechaza una reserva pendiente indicando el motivo
+// POST /api/approvals/:id/reject
+func (ctrl *ApprovalController) RejectBooking(c *gin.Context) {
+	ctrl.decide(c, ctrl.bookingService.RejectBooking, "Reserva rechazada exitosamente")
+}
+
+// decide lee el ID y el motivo de la solicitud y aplica la decisión
+func (ctrl *ApprovalController) decide(
+	c *gin.Context,
+	decision func(id uint, deciderID uint, isAdmin bool, req *dto.BookingDecisionRequest) (*dto.BookingResponse, error),
+	message string,
+) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	var req dto.BookingDecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+			return
+		}
+	}
+
+	booking, err := decision(uint(id), userID.(uint), isAdmin, &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, message, booking)
+}
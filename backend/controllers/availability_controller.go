@@ -6,6 +6,7 @@ import (
 	"Reservify/utils"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,8 +19,10 @@ func NewAvailabilityController(availabilityService *services.AvailabilityService
 	return &AvailabilityController{availabilityService: availabilityService}
 }
 
-// GetAvailabilityByResource obtiene la disponibilidad de un recurso
+// GetAvailabilityByResource obtiene la disponibilidad de un recurso.
+// Con from y to devuelve el horario efectivo de cada día (excepciones y cierres incluidos).
 // GET /api/resources/:id/availability
+// GET /api/resources/:id/availability?from=2025-06-01&to=2025-06-07
 func (ctrl *AvailabilityController) GetAvailabilityByResource(c *gin.Context) {
 	idParam := c.Param("id")
 	resourceID, err := strconv.ParseUint(idParam, 10, 32)
@@ -28,6 +31,22 @@ func (ctrl *AvailabilityController) GetAvailabilityByResource(c *gin.Context) {
 		return
 	}
 
+	if from, to := c.Query("from"), c.Query("to"); from != "" || to != "" {
+		if from == "" || to == "" {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Se requieren from y to", nil)
+			return
+		}
+
+		schedule, err := ctrl.availabilityService.GetEffectiveSchedule(uint(resourceID), from, to)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+
+		utils.SuccessResponse(c, http.StatusOK, "Horario efectivo obtenido exitosamente", schedule)
+		return
+	}
+
 	availability, err := ctrl.availabilityService.GetAvailabilityByResource(uint(resourceID))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
@@ -37,6 +56,106 @@ func (ctrl *AvailabilityController) GetAvailabilityByResource(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Disponibilidad obtenida exitosamente", availability)
 }
 
+// GetSeatAvailability obtiene las plazas libres de un recurso en un intervalo
+// GET /api/resources/:id/seats?start=2025-01-15T10:00:00Z&end=2025-01-15T12:00:00Z
+func (ctrl *AvailabilityController) GetSeatAvailability(c *gin.Context) {
+	idParam := c.Param("id")
+	resourceID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Fecha de inicio inválida", err)
+		return
+	}
+	end, err := time.Parse(time.RFC3339, c.Query("end"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Fecha de fin inválida", err)
+		return
+	}
+
+	seats, err := ctrl.availabilityService.GetSeatAvailability(uint(resourceID), start, end)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Plazas disponibles obtenidas exitosamente", seats)
+}
+
+// GetFreeSlots obtiene las ventanas libres de un recurso
+// GET /api/resources/:id/free-slots?from=2025-01-15T00:00:00Z&to=2025-01-22T00:00:00Z&duration=60&granularity=30&seats=1
+func (ctrl *AvailabilityController) GetFreeSlots(c *gin.Context) {
+	idParam := c.Param("id")
+	resourceID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, c.Query("from"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Fecha de inicio inválida", err)
+		return
+	}
+	to, err := time.Parse(time.RFC3339, c.Query("to"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Fecha de fin inválida", err)
+		return
+	}
+
+	// duration y granularity se expresan en minutos
+	duration, err := strconv.Atoi(c.DefaultQuery("duration", "0"))
+	if err != nil || duration < 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Duración inválida", err)
+		return
+	}
+	granularity, err := strconv.Atoi(c.DefaultQuery("granularity", "0"))
+	if err != nil || granularity < 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Granularidad inválida", err)
+		return
+	}
+	seats, err := strconv.Atoi(c.DefaultQuery("seats", "1"))
+	if err != nil || seats < 1 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Número de plazas inválido", err)
+		return
+	}
+
+	freeSlots, err := ctrl.availabilityService.GetFreeSlots(
+		uint(resourceID), from, to,
+		time.Duration(duration)*time.Minute,
+		time.Duration(granularity)*time.Minute,
+		seats,
+	)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Horarios libres obtenidos exitosamente", freeSlots)
+}
+
+// SearchAvailableResources busca recursos libres que cumplan los filtros
+// GET /api/resources/search?start=2025-01-16T14:00:00Z&end=2025-01-16T15:30:00Z&category=meeting&min_capacity=8&max_price_per_hour=50
+func (ctrl *AvailabilityController) SearchAvailableResources(c *gin.Context) {
+	var req dto.ResourceSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Parámetros inválidos", err)
+		return
+	}
+
+	resources, err := ctrl.availabilityService.SearchAvailableResources(&req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Recursos disponibles obtenidos exitosamente", resources)
+}
+
 // CreateAvailability crea un horario de disponibilidad (solo admin)
 // POST /api/admin/resources/:id/availability
 func (ctrl *AvailabilityController) CreateAvailability(c *gin.Context) {
@@ -62,6 +181,42 @@ func (ctrl *AvailabilityController) CreateAvailability(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusCreated, "Horario creado exitosamente", availability)
 }
 
+// ReplaceSchedule reemplaza todos los horarios semanales de un recurso (solo admin).
+// Con dry_run=true solo informa qué reservas futuras quedarían fuera del nuevo horario.
+// PUT /api/admin/resources/:id/schedule?dry_run=true
+func (ctrl *AvailabilityController) ReplaceSchedule(c *gin.Context) {
+	idParam := c.Param("id")
+	resourceID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Valor de dry_run inválido", err)
+		return
+	}
+
+	var req dto.ReplaceScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	schedule, err := ctrl.availabilityService.ReplaceSchedule(uint(resourceID), &req, dryRun)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	message := "Horarios reemplazados exitosamente"
+	if dryRun {
+		message = "Simulación de horarios completada"
+	}
+	utils.SuccessResponse(c, http.StatusOK, message, schedule)
+}
+
 // UpdateAvailability actualiza un horario (solo admin)
 // PUT /api/admin/availability/:id
 func (ctrl *AvailabilityController) UpdateAvailability(c *gin.Context) {
@@ -104,3 +259,98 @@ func (ctrl *AvailabilityController) DeleteAvailability(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Horario eliminado exitosamente", nil)
 }
+
+// GetOverrides obtiene las excepciones de horario de un recurso (solo admin)
+// GET /api/admin/resources/:id/availability/overrides?from=2025-06-01&to=2025-06-30
+func (ctrl *AvailabilityController) GetOverrides(c *gin.Context) {
+	idParam := c.Param("id")
+	resourceID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	overrides, err := ctrl.availabilityService.GetOverrides(uint(resourceID), c.Query("from"), c.Query("to"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Excepciones de horario obtenidas exitosamente", overrides)
+}
+
+// CreateOverride crea una excepción de horario para una fecha (solo admin)
+// POST /api/admin/resources/:id/availability/overrides
+func (ctrl *AvailabilityController) CreateOverride(c *gin.Context) {
+	idParam := c.Param("id")
+	resourceID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.AvailabilityOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	override, err := ctrl.availabilityService.CreateOverride(uint(resourceID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Excepción de horario creada exitosamente", override)
+}
+
+// UpdateOverride actualiza una excepción de horario (solo admin)
+// PUT /api/admin/resources/:id/availability/overrides/:overrideId
+func (ctrl *AvailabilityController) UpdateOverride(c *gin.Context) {
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+	overrideID, err := strconv.ParseUint(c.Param("overrideId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID de excepción inválido", err)
+		return
+	}
+
+	var req dto.AvailabilityOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	override, err := ctrl.availabilityService.UpdateOverride(uint(resourceID), uint(overrideID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Excepción de horario actualizada exitosamente", override)
+}
+
+// DeleteOverride elimina una excepción de horario (solo admin)
+// DELETE /api/admin/resources/:id/availability/overrides/:overrideId
+func (ctrl *AvailabilityController) DeleteOverride(c *gin.Context) {
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+	overrideID, err := strconv.ParseUint(c.Param("overrideId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID de excepción inválido", err)
+		return
+	}
+
+	if err := ctrl.availabilityService.DeleteOverride(uint(resourceID), uint(overrideID)); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Excepción de horario eliminada exitosamente", nil)
+}
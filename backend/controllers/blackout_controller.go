@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlackoutController struct {
+	blackoutService *services.BlackoutService
+}
+
+func NewBlackoutController(blackoutService *services.BlackoutService) *BlackoutController {
+	return &BlackoutController{blackoutService: blackoutService}
+}
+
+// GetAllBlackouts obtiene todos los cierres (solo admin)
+// GET /api/admin/blackouts?page=1&page_size=10&search=feriado
+func (ctrl *BlackoutController) GetAllBlackouts(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	blackouts, total, err := ctrl.blackoutService.GetAllBlackouts(params)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener cierres", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, http.StatusOK, "Cierres obtenidos exitosamente", blackouts, total, params)
+}
+
+// GetBlackoutByID obtiene un cierre con las reservas que afecta (solo admin)
+// GET /api/admin/blackouts/:id
+func (ctrl *BlackoutController) GetBlackoutByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	blackout, err := ctrl.blackoutService.GetBlackoutByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cierre obtenido exitosamente", blackout)
+}
+
+// CreateBlackout crea un cierre (solo admin)
+// POST /api/admin/blackouts
+func (ctrl *BlackoutController) CreateBlackout(c *gin.Context) {
+	var req dto.BlackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	blackout, err := ctrl.blackoutService.CreateBlackout(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Cierre creado exitosamente", blackout)
+}
+
+// UpdateBlackout actualiza un cierre (solo admin)
+// PUT /api/admin/blackouts/:id
+func (ctrl *BlackoutController) UpdateBlackout(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.BlackoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	blackout, err := ctrl.blackoutService.UpdateBlackout(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cierre actualizado exitosamente", blackout)
+}
+
+// DeleteBlackout elimina un cierre (solo admin)
+// DELETE /api/admin/blackouts/:id
+func (ctrl *BlackoutController) DeleteBlackout(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	if err := ctrl.blackoutService.DeleteBlackout(uint(id)); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cierre eliminado exitosamente", nil)
+}
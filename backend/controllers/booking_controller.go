@@ -4,6 +4,7 @@ import (
 	"Reservify/dto"
 	"Reservify/services"
 	"Reservify/utils"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -84,6 +85,29 @@ func (ctrl *BookingController) GetBookingByID(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Reserva obtenida exitosamente", booking)
 }
 
+// GetBookingHistory obtiene el historial de cambios de una reserva (titular o admin)
+// GET /api/bookings/:id/history
+func (ctrl *BookingController) GetBookingHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	events, err := ctrl.bookingService.GetBookingHistory(uint(id), userID.(uint), isAdmin)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Historial obtenido exitosamente", events)
+}
+
 // CreateBooking crea una nueva reserva
 // POST /api/bookings
 func (ctrl *BookingController) CreateBooking(c *gin.Context) {
@@ -95,17 +119,70 @@ func (ctrl *BookingController) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	// Si trae regla de recurrencia se crea una serie completa
+	if req.Recurrence != nil {
+		series, err := ctrl.bookingService.CreateRecurringBooking(userID.(uint), &req)
+		if err != nil {
+			bookingErrorResponse(c, err)
+			return
+		}
+
+		utils.SuccessResponse(c, http.StatusCreated, "Serie de reservas creada exitosamente", series)
+		return
+	}
+
 	booking, err := ctrl.bookingService.CreateBooking(userID.(uint), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		bookingErrorResponse(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, "Reserva creada exitosamente", booking)
 }
 
-// UpdateBooking actualiza una reserva
-// PUT /api/bookings/:id
+// HoldBooking retiene un horario unos minutos mientras el usuario completa el checkout
+// POST /api/bookings/hold
+func (ctrl *BookingController) HoldBooking(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.HoldBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	booking, err := ctrl.bookingService.CreateHold(userID.(uint), &req)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Horario retenido exitosamente", booking)
+}
+
+// CompleteHold convierte una retención en una reserva pendiente de confirmación
+// POST /api/bookings/:id/hold/complete
+func (ctrl *BookingController) CompleteHold(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	booking, err := ctrl.bookingService.CompleteHold(uint(id), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva completada exitosamente", booking)
+}
+
+// UpdateBooking actualiza una reserva (o varias ocurrencias de su serie)
+// PUT /api/bookings/:id?scope=this|following|all
 func (ctrl *BookingController) UpdateBooking(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
@@ -124,17 +201,29 @@ func (ctrl *BookingController) UpdateBooking(c *gin.Context) {
 		return
 	}
 
+	scope := c.DefaultQuery("scope", services.SeriesScopeThis)
+	if scope != services.SeriesScopeThis {
+		bookings, err := ctrl.bookingService.UpdateBookingSeries(uint(id), userID.(uint), &req, scope, isAdmin)
+		if err != nil {
+			bookingErrorResponse(c, err)
+			return
+		}
+
+		utils.SuccessResponse(c, http.StatusOK, "Serie de reservas actualizada exitosamente", bookings)
+		return
+	}
+
 	booking, err := ctrl.bookingService.UpdateBooking(uint(id), userID.(uint), &req, isAdmin)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		bookingErrorResponse(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Reserva actualizada exitosamente", booking)
 }
 
-// CancelBooking cancela una reserva
-// DELETE /api/bookings/:id
+// CancelBooking cancela una reserva (o varias ocurrencias de su serie)
+// DELETE /api/bookings/:id?scope=this|following|all&reason=...
 func (ctrl *BookingController) CancelBooking(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
@@ -147,12 +236,316 @@ func (ctrl *BookingController) CancelBooking(c *gin.Context) {
 	userRole, _ := c.Get("user_role")
 	isAdmin := userRole == "admin"
 
-	if err := ctrl.bookingService.CancelBooking(uint(id), userID.(uint), isAdmin); err != nil {
+	// Solo un admin puede condonar el cargo de cancelación
+	waiveFee := isAdmin && c.Query("waive_fee") == "true"
+	reason := c.Query("reason")
+
+	scope := c.DefaultQuery("scope", services.SeriesScopeThis)
+	if scope != services.SeriesScopeThis {
+		cancelled, err := ctrl.bookingService.CancelBookingSeries(uint(id), userID.(uint), scope, isAdmin, waiveFee, reason)
+		if err != nil {
+			bookingErrorResponse(c, err)
+			return
+		}
+
+		utils.SuccessResponse(c, http.StatusOK, "Serie de reservas cancelada exitosamente", gin.H{
+			"cancelled": cancelled,
+		})
+		return
+	}
+
+	booking, err := ctrl.bookingService.CancelBooking(uint(id), userID.(uint), isAdmin, waiveFee, reason)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva cancelada exitosamente", booking)
+}
+
+// CreateBookingForUser crea una reserva en nombre de otro usuario (solo admin)
+// POST /api/admin/bookings
+func (ctrl *BookingController) CreateBookingForUser(c *gin.Context) {
+	adminID, _ := c.Get("user_id")
+
+	var req dto.AdminCreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	booking, err := ctrl.bookingService.CreateBookingForUser(adminID.(uint), &req)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Reserva creada exitosamente", booking)
+}
+
+// UpdateBookingForUser modifica una reserva en nombre de su titular (solo admin)
+// PUT /api/admin/bookings/:id
+func (ctrl *BookingController) UpdateBookingForUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	adminID, _ := c.Get("user_id")
+
+	var req dto.AdminUpdateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	booking, err := ctrl.bookingService.UpdateBookingForUser(uint(id), adminID.(uint), &req)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva actualizada exitosamente", booking)
+}
+
+// CancelBookingForUser cancela una reserva en nombre de su titular y le avisa (solo admin)
+// DELETE /api/admin/bookings/:id?waive_fee=true&reason=...
+func (ctrl *BookingController) CancelBookingForUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	adminID, _ := c.Get("user_id")
+
+	booking, err := ctrl.bookingService.CancelBookingForUser(uint(id), adminID.(uint), c.Query("waive_fee") == "true", c.Query("reason"))
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva cancelada exitosamente", booking)
+}
+
+// PreviewCancellation muestra el cargo y el reembolso de cancelar la reserva ahora
+// GET /api/bookings/:id/cancellation-preview?waive_fee=true
+func (ctrl *BookingController) PreviewCancellation(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+	waiveFee := isAdmin && c.Query("waive_fee") == "true"
+
+	preview, err := ctrl.bookingService.PreviewCancellation(uint(id), userID.(uint), isAdmin, waiveFee)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Vista previa de cancelación obtenida exitosamente", preview)
+}
+
+// CheckIn registra la llegada a una reserva confirmada con el código de su QR
+// POST /api/bookings/:id/check-in
+func (ctrl *BookingController) CheckIn(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	var req dto.CheckInRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+			return
+		}
+	}
+
+	booking, err := ctrl.bookingService.CheckIn(uint(id), userID.(uint), isAdmin, req.Token)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Llegada registrada exitosamente", booking)
+}
+
+// EndBooking termina ahora una reserva en curso y libera el resto del horario
+// POST /api/bookings/:id/end
+func (ctrl *BookingController) EndBooking(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	var req dto.EndBookingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+			return
+		}
+	}
+
+	booking, err := ctrl.bookingService.EndBooking(uint(id), userID.(uint), isAdmin, &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva terminada exitosamente", booking)
+}
+
+// ExtendBooking amplía el fin de una reserva en curso si el horario sigue libre
+// POST /api/bookings/:id/extend
+func (ctrl *BookingController) ExtendBooking(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.ExtendBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	booking, err := ctrl.bookingService.ExtendBooking(uint(id), userID.(uint), isAdmin, &req)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva ampliada exitosamente", booking)
+}
+
+// GetAttendees obtiene los invitados de una reserva
+// GET /api/bookings/:id/attendees
+func (ctrl *BookingController) GetAttendees(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	attendees, err := ctrl.bookingService.GetAttendees(uint(id), userID.(uint), isAdmin)
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Reserva cancelada exitosamente", nil)
+	utils.SuccessResponse(c, http.StatusOK, "Invitados obtenidos exitosamente", attendees)
+}
+
+// SetAttendees reemplaza la lista de invitados de una reserva
+// PUT /api/bookings/:id/attendees
+func (ctrl *BookingController) SetAttendees(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.BookingAttendeesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	attendees, err := ctrl.bookingService.SetAttendees(uint(id), userID.(uint), isAdmin, &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Invitados actualizados exitosamente", attendees)
+}
+
+// RespondInvitation registra la respuesta del usuario a su invitación a una reserva
+// POST /api/bookings/:id/rsvp
+func (ctrl *BookingController) RespondInvitation(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.RSVPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	attendee, err := ctrl.bookingService.RespondInvitation(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Respuesta registrada exitosamente", attendee)
+}
+
+// GetCheckInCode obtiene el código firmado de check-in y el contenido del QR de una reserva
+// GET /api/bookings/:id/check-in-code
+func (ctrl *BookingController) GetCheckInCode(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	code, err := ctrl.bookingService.GetCheckInCode(uint(id), userID.(uint), isAdmin)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Código de check-in obtenido exitosamente", code)
 }
 
 // ChangeBookingStatus cambia el estado de una reserva (solo admin)
@@ -171,7 +564,9 @@ func (ctrl *BookingController) ChangeBookingStatus(c *gin.Context) {
 		return
 	}
 
-	booking, err := ctrl.bookingService.ChangeBookingStatus(uint(id), req.Status)
+	userID, _ := c.Get("user_id")
+
+	booking, err := ctrl.bookingService.ChangeBookingStatus(uint(id), userID.(uint), &req)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
 		return
@@ -191,3 +586,27 @@ func (ctrl *BookingController) GetBookingStats(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Estadísticas obtenidas exitosamente", stats)
 }
+
+// bookingErrorResponse responde con el error del servicio de reservas; las infracciones de política
+// y de cuota incluyen la regla incumplida para que el frontend pueda identificarla
+func bookingErrorResponse(c *gin.Context, err error) {
+	var violation *services.PolicyViolation
+	if errors.As(err, &violation) {
+		utils.ErrorDataResponse(c, http.StatusUnprocessableEntity, err.Error(), violation)
+		return
+	}
+	var exceeded *services.QuotaExceeded
+	if errors.As(err, &exceeded) {
+		utils.ErrorDataResponse(c, http.StatusUnprocessableEntity, err.Error(), exceeded)
+		return
+	}
+	utils.ErrorResponse(c, bookingErrorStatus(err), err.Error(), nil)
+}
+
+// bookingErrorStatus traduce los errores del servicio de reservas a códigos HTTP
+func bookingErrorStatus(err error) int {
+	if errors.Is(err, services.ErrBookingConflict) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingPolicyController struct {
+	policyService *services.BookingPolicyService
+}
+
+func NewBookingPolicyController(policyService *services.BookingPolicyService) *BookingPolicyController {
+	return &BookingPolicyController{policyService: policyService}
+}
+
+// GetResourcePolicy obtiene la política efectiva de un recurso (pública, para prevalidar en el frontend)
+// GET /api/resources/:id/policy
+func (ctrl *BookingPolicyController) GetResourcePolicy(c *gin.Context) {
+	idParam := c.Param("id")
+	resourceID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	policy, err := ctrl.policyService.GetEffectivePolicy(uint(resourceID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Política obtenida exitosamente", policy)
+}
+
+// GetAllPolicies obtiene todas las políticas configuradas (solo admin)
+// GET /api/admin/policies
+func (ctrl *BookingPolicyController) GetAllPolicies(c *gin.Context) {
+	policies, err := ctrl.policyService.GetAllPolicies()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener políticas", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Políticas obtenidas exitosamente", policies)
+}
+
+// SavePolicy crea o reemplaza la política de un recurso, de una categoría o la global (solo admin)
+// PUT /api/admin/policies
+func (ctrl *BookingPolicyController) SavePolicy(c *gin.Context) {
+	var req dto.BookingPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	policy, err := ctrl.policyService.SavePolicy(&req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Política guardada exitosamente", policy)
+}
+
+// DeletePolicy elimina una política (solo admin)
+// DELETE /api/admin/policies/:id
+func (ctrl *BookingPolicyController) DeletePolicy(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	if err := ctrl.policyService.DeletePolicy(uint(id)); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Política eliminada exitosamente", nil)
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	jobService *services.JobService
+}
+
+func NewJobController(jobService *services.JobService) *JobController {
+	return &JobController{jobService: jobService}
+}
+
+// GetJobs obtiene la última ejecución de cada tarea programada (solo admin)
+// GET /api/admin/jobs
+func (ctrl *JobController) GetJobs(c *gin.Context) {
+	runs, err := ctrl.jobService.GetLatestRuns()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener tareas", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tareas obtenidas exitosamente", runs)
+}
+
+// GetJobRuns obtiene el historial de ejecuciones (solo admin)
+// GET /api/admin/jobs/runs?job=complete_bookings&page=1&page_size=10
+func (ctrl *JobController) GetJobRuns(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	runs, total, err := ctrl.jobService.GetRuns(c.Query("job"), params)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener ejecuciones", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, http.StatusOK, "Ejecuciones obtenidas exitosamente", runs, total, params)
+}
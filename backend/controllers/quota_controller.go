@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuotaController struct {
+	quotaService *services.QuotaService
+}
+
+func NewQuotaController(quotaService *services.QuotaService) *QuotaController {
+	return &QuotaController{quotaService: quotaService}
+}
+
+// GetMyQuota obtiene el uso de cuotas del usuario autenticado
+// GET /api/users/me/quota
+func (ctrl *QuotaController) GetMyQuota(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	quota, err := ctrl.quotaService.GetMyQuota(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener cuotas", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cuotas obtenidas exitosamente", quota)
+}
+
+// GetAllQuotas obtiene todas las cuotas configuradas (solo admin)
+// GET /api/admin/quotas
+func (ctrl *QuotaController) GetAllQuotas(c *gin.Context) {
+	quotas, err := ctrl.quotaService.GetAllQuotas()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener cuotas", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cuotas obtenidas exitosamente", quotas)
+}
+
+// SaveQuota crea o reemplaza la cuota de un usuario, de un rol o la global (solo admin)
+// PUT /api/admin/quotas
+func (ctrl *QuotaController) SaveQuota(c *gin.Context) {
+	var req dto.QuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	quota, err := ctrl.quotaService.SaveQuota(&req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cuota guardada exitosamente", quota)
+}
+
+// DeleteQuota elimina una cuota (solo admin)
+// DELETE /api/admin/quotas/:id
+func (ctrl *QuotaController) DeleteQuota(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	if err := ctrl.quotaService.DeleteQuota(uint(id)); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cuota eliminada exitosamente", nil)
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReservationController struct {
+	bookingService *services.BookingService
+}
+
+func NewReservationController(bookingService *services.BookingService) *ReservationController {
+	return &ReservationController{bookingService: bookingService}
+}
+
+// CreateReservation reserva varios recursos a la vez: se crean todas las reservas o ninguna
+// POST /api/reservations
+func (ctrl *ReservationController) CreateReservation(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.ReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	reservation, err := ctrl.bookingService.CreateReservation(userID.(uint), &req)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Reserva múltiple creada exitosamente", reservation)
+}
+
+// GetMyReservations obtiene las reservas múltiples del usuario autenticado
+// GET /api/reservations/my
+func (ctrl *ReservationController) GetMyReservations(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	reservations, err := ctrl.bookingService.GetMyReservations(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener las reservas múltiples", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reservas múltiples obtenidas exitosamente", reservations)
+}
+
+// GetReservation obtiene una reserva múltiple con sus reservas y el precio combinado
+// GET /api/reservations/:id
+func (ctrl *ReservationController) GetReservation(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	reservation, err := ctrl.bookingService.GetReservation(uint(id), userID.(uint), isAdmin)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva múltiple obtenida exitosamente", reservation)
+}
+
+// UpdateReservation modifica los recursos y horarios de una reserva múltiple como una unidad
+// PUT /api/reservations/:id
+func (ctrl *ReservationController) UpdateReservation(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	var req dto.ReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	reservation, err := ctrl.bookingService.UpdateReservation(uint(id), userID.(uint), &req, isAdmin)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva múltiple actualizada exitosamente", reservation)
+}
+
+// CancelReservation cancela todas las reservas de una reserva múltiple
+// DELETE /api/reservations/:id
+func (ctrl *ReservationController) CancelReservation(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	// Solo un admin puede condonar el cargo de cancelación
+	waiveFee := isAdmin && c.Query("waive_fee") == "true"
+
+	reservation, err := ctrl.bookingService.CancelReservation(uint(id), userID.(uint), isAdmin, waiveFee)
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reserva múltiple cancelada exitosamente", reservation)
+}
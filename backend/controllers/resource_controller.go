@@ -118,6 +118,50 @@ func (ctrl *ResourceController) UpdateResource(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Recurso actualizado exitosamente", resource)
 }
 
+// GetApprovers obtiene los aprobadores designados de un recurso (solo admin)
+// GET /api/admin/resources/:id/approvers
+func (ctrl *ResourceController) GetApprovers(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	approvers, err := ctrl.resourceService.GetApprovers(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Aprobadores obtenidos exitosamente", approvers)
+}
+
+// SetApprovers reemplaza los aprobadores designados de un recurso (solo admin)
+// PUT /api/admin/resources/:id/approvers
+func (ctrl *ResourceController) SetApprovers(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.ResourceApproversRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	approvers, err := ctrl.resourceService.SetApprovers(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Aprobadores actualizados exitosamente", approvers)
+}
+
 // DeleteResource elimina un recurso (solo admin)
 // DELETE /api/admin/resources/:id
 func (ctrl *ResourceController) DeleteResource(c *gin.Context) {
@@ -161,3 +205,26 @@ func (ctrl *ResourceController) GetResourceStats(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Estadísticas obtenidas exitosamente", stats)
 }
+
+// UpdateCategoryBuffers aplica márgenes de preparación y limpieza a toda una categoría (solo admin)
+// PUT /api/admin/categories/:category/buffers
+func (ctrl *ResourceController) UpdateCategoryBuffers(c *gin.Context) {
+	category := c.Param("category")
+
+	var req dto.CategoryBuffersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	updated, err := ctrl.resourceService.UpdateCategoryBuffers(category, &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Márgenes actualizados exitosamente", gin.H{
+		"category":          category,
+		"updated_resources": updated,
+	})
+}
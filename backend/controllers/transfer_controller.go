@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferController struct {
+	bookingService *services.BookingService
+}
+
+func NewTransferController(bookingService *services.BookingService) *TransferController {
+	return &TransferController{bookingService: bookingService}
+}
+
+// ProposeTransfer propone pasar una reserva a otro usuario
+// POST /api/bookings/:id/transfer
+func (ctrl *TransferController) ProposeTransfer(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.BookingTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	transfer, err := ctrl.bookingService.ProposeTransfer(uint(id), userID.(uint), isAdmin, &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Transferencia propuesta exitosamente", transfer)
+}
+
+// GetMyTransfers obtiene las transferencias propuestas y recibidas por el usuario autenticado
+// GET /api/transfers/my
+func (ctrl *TransferController) GetMyTransfers(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	transfers, err := ctrl.bookingService.GetMyTransfers(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener las transferencias", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Transferencias obtenidas exitosamente", transfers)
+}
+
+// AcceptTransfer acepta una transferencia recibida y hace al usuario titular de la reserva
+// POST /api/transfers/:id/accept
+func (ctrl *TransferController) AcceptTransfer(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	booking, err := ctrl.bookingService.AcceptTransfer(uint(id), userID.(uint))
+	if err != nil {
+		bookingErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Transferencia aceptada exitosamente", booking)
+}
+
+// DeclineTransfer rechaza una transferencia recibida
+// POST /api/transfers/:id/decline
+func (ctrl *TransferController) DeclineTransfer(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	transfer, err := ctrl.bookingService.DeclineTransfer(uint(id), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Transferencia rechazada exitosamente", transfer)
+}
+
+// CancelTransfer retira una transferencia pendiente
+// DELETE /api/transfers/:id
+func (ctrl *TransferController) CancelTransfer(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	transfer, err := ctrl.bookingService.CancelTransfer(uint(id), userID.(uint), isAdmin)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Transferencia retirada exitosamente", transfer)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"Reservify/dto"
+	"Reservify/services"
+	"Reservify/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistController struct {
+	waitlistService *services.WaitlistService
+}
+
+func NewWaitlistController(waitlistService *services.WaitlistService) *WaitlistController {
+	return &WaitlistController{waitlistService: waitlistService}
+}
+
+// JoinWaitlist apunta al usuario a la lista de espera de un horario ocupado
+// POST /api/waitlist
+func (ctrl *WaitlistController) JoinWaitlist(c *gin.Context) {
+	var req dto.JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	entry, err := ctrl.waitlistService.JoinWaitlist(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Te uniste a la lista de espera", entry)
+}
+
+// GetMyWaitlist obtiene las entradas de lista de espera del usuario autenticado
+// GET /api/waitlist/my
+func (ctrl *WaitlistController) GetMyWaitlist(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	entries, err := ctrl.waitlistService.GetMyWaitlist(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Error al obtener la lista de espera", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lista de espera obtenida exitosamente", entries)
+}
+
+// LeaveWaitlist saca una entrada de la lista de espera
+// DELETE /api/waitlist/:id
+func (ctrl *WaitlistController) LeaveWaitlist(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	isAdmin := userRole == "admin"
+
+	if err := ctrl.waitlistService.LeaveWaitlist(uint(id), userID.(uint), isAdmin); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Saliste de la lista de espera", nil)
+}
+
+// GetResourceWaitlist obtiene la lista de espera de un recurso (solo admin)
+// GET /api/admin/resources/:id/waitlist
+func (ctrl *WaitlistController) GetResourceWaitlist(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	entries, err := ctrl.waitlistService.GetResourceWaitlist(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lista de espera obtenida exitosamente", entries)
+}
+
+// ReorderWaitlist cambia el orden de la lista de espera de un recurso (solo admin)
+// PUT /api/admin/resources/:id/waitlist/order
+func (ctrl *WaitlistController) ReorderWaitlist(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err)
+		return
+	}
+
+	var req dto.ReorderWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Datos inválidos", err)
+		return
+	}
+
+	entries, err := ctrl.waitlistService.ReorderWaitlist(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lista de espera reordenada exitosamente", entries)
+}
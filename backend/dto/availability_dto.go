@@ -25,3 +25,115 @@ type AvailabilityResponse struct {
 	EndTime    string    `json:"end_time"`
 	CreatedAt  time.Time `json:"created_at"`
 }
+
+// SeatAvailabilityResponse representa las plazas libres de un recurso en un intervalo
+type SeatAvailabilityResponse struct {
+	ResourceID     uint      `json:"resource_id"`
+	BookingMode    string    `json:"booking_mode"`
+	Capacity       int       `json:"capacity"`
+	BookedSeats    int       `json:"booked_seats"` // Máximo de plazas ocupadas a la vez
+	RemainingSeats int       `json:"remaining_seats"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+}
+
+// FreeSlot representa una ventana concreta en la que se puede reservar
+type FreeSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FreeSlotsResponse representa las ventanas libres de un recurso en un rango de fechas
+type FreeSlotsResponse struct {
+	ResourceID      uint       `json:"resource_id"`
+	Timezone        string     `json:"timezone"` // Las ventanas se expresan en esta zona
+	From            time.Time  `json:"from"`
+	To              time.Time  `json:"to"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Seats           int        `json:"seats"`
+	Slots           []FreeSlot `json:"slots"`
+}
+
+// ResourceSearchRequest representa los filtros para buscar recursos libres
+type ResourceSearchRequest struct {
+	Start           time.Time `form:"start" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	End             time.Time `form:"end" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	Category        string    `form:"category"`
+	MinCapacity     int       `form:"min_capacity" binding:"omitempty,min=1"` // Número de personas
+	MaxPricePerHour *float64  `form:"max_price_per_hour" binding:"omitempty,min=0"`
+}
+
+// ResourceSearchResult representa un recurso libre para el intervalo buscado
+type ResourceSearchResult struct {
+	ID             uint    `json:"id"`
+	Name           string  `json:"name"`
+	Capacity       int     `json:"capacity"`
+	PricePerHour   float64 `json:"price_per_hour"`
+	Category       string  `json:"category"`
+	ImageURL       string  `json:"image_url"`
+	BookingMode    string  `json:"booking_mode"`
+	Timezone       string  `json:"timezone"`
+	RemainingSeats int     `json:"remaining_seats"`
+}
+
+// AvailabilityOverrideRequest representa una franja especial para una fecha concreta
+type AvailabilityOverrideRequest struct {
+	Date      string `json:"date" binding:"required"`       // Formato: "2025-06-14"
+	StartTime string `json:"start_time" binding:"required"` // Formato: "10:00:00" o "10:00"
+	EndTime   string `json:"end_time" binding:"required"`   // Formato: "14:00:00" o "14:00"
+	Reason    string `json:"reason" binding:"max=255"`
+}
+
+// AvailabilityOverrideResponse representa la respuesta de una excepción de horario
+type AvailabilityOverrideResponse struct {
+	ID         uint      `json:"id"`
+	ResourceID uint      `json:"resource_id"`
+	Date       string    `json:"date"`
+	StartTime  string    `json:"start_time"`
+	EndTime    string    `json:"end_time"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ScheduleWindow representa una franja abierta concreta
+type ScheduleWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// EffectiveDay representa el horario real de un día: plantilla semanal o excepción, menos cierres
+type EffectiveDay struct {
+	Date      string           `json:"date"`
+	DayOfWeek string           `json:"day_of_week"`
+	Source    string           `json:"source"` // "weekly" u "override"
+	Windows   []ScheduleWindow `json:"windows"`
+	Closures  []string         `json:"closures,omitempty"` // Motivos de los cierres que afectan al día
+}
+
+// EffectiveScheduleResponse representa el horario efectivo de un recurso en un rango de fechas
+type EffectiveScheduleResponse struct {
+	ResourceID uint           `json:"resource_id"`
+	Timezone   string         `json:"timezone"`
+	From       string         `json:"from"`
+	To         string         `json:"to"`
+	Days       []EffectiveDay `json:"days"`
+}
+
+// ReplaceScheduleRequest representa la plantilla semanal completa de un recurso
+type ReplaceScheduleRequest struct {
+	Slots []CreateAvailabilityRequest `json:"slots" binding:"required,dive"` // Una lista vacía deja el recurso sin horarios
+}
+
+// ReplaceScheduleResponse representa el resultado de reemplazar (o simular) la plantilla semanal
+type ReplaceScheduleResponse struct {
+	ResourceID      uint                   `json:"resource_id"`
+	DryRun          bool                   `json:"dry_run"`
+	Slots           []AvailabilityResponse `json:"slots"`
+	OutsideBookings []ScheduleConflict     `json:"outside_bookings"` // Reservas futuras que quedarían fuera del horario
+}
+
+// ScheduleConflict representa una reserva que no encaja en el nuevo horario
+type ScheduleConflict struct {
+	AffectedBooking
+	Reason string `json:"reason"`
+}
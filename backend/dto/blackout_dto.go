@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// BlackoutRequest representa los datos para crear o actualizar un cierre.
+// Sin resource_id ni category el cierre aplica a todos los recursos.
+type BlackoutRequest struct {
+	ResourceID     *uint      `json:"resource_id"`
+	Category       string     `json:"category"`
+	AllDay         bool       `json:"all_day"`
+	StartDate      string     `json:"start_date"` // Formato: "2025-12-25" (solo si all_day)
+	EndDate        string     `json:"end_date"`   // Inclusive; si se omite se usa start_date
+	StartDatetime  *time.Time `json:"start_datetime"`
+	EndDatetime    *time.Time `json:"end_datetime"`
+	Reason         string     `json:"reason" binding:"required,max=255"`
+	CancelAffected bool       `json:"cancel_affected"` // Cancela y notifica las reservas afectadas
+}
+
+// AffectedBooking representa una reserva activa que coincide con un cierre
+type AffectedBooking struct {
+	ID            uint      `json:"id"`
+	UserID        uint      `json:"user_id"`
+	ResourceID    uint      `json:"resource_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	Status        string    `json:"status"`
+}
+
+// BlackoutResponse representa la respuesta de un cierre
+type BlackoutResponse struct {
+	ID               uint              `json:"id"`
+	ResourceID       *uint             `json:"resource_id"`
+	Category         string            `json:"category"`
+	AllDay           bool              `json:"all_day"`
+	StartDate        string            `json:"start_date,omitempty"`
+	EndDate          string            `json:"end_date,omitempty"`
+	StartDatetime    *time.Time        `json:"start_datetime,omitempty"`
+	EndDatetime      *time.Time        `json:"end_datetime,omitempty"`
+	Reason           string            `json:"reason"`
+	CreatedAt        time.Time         `json:"created_at"`
+	AffectedBookings []AffectedBooking `json:"affected_bookings,omitempty"`
+	CancelledCount   int               `json:"cancelled_count"`
+}
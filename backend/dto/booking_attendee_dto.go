@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// AttendeeRequest representa un invitado: un usuario registrado (user_id) o uno externo (email)
+type AttendeeRequest struct {
+	UserID     *uint  `json:"user_id"`
+	Email      string `json:"email" binding:"omitempty,email,max=255"`
+	Name       string `json:"name" binding:"max=255"`
+	RSVPStatus string `json:"rsvp_status" binding:"omitempty,oneof=pending accepted tentative declined"` // Solo externos: respuesta que anota el titular
+}
+
+// BookingAttendeesRequest representa la lista completa de invitados de una reserva
+type BookingAttendeesRequest struct {
+	Attendees []AttendeeRequest `json:"attendees" binding:"dive"` // Lista vacía para quitar a todos
+}
+
+// RSVPRequest representa la respuesta de un usuario a una invitación
+type RSVPRequest struct {
+	Status string `json:"status" binding:"required,oneof=accepted tentative declined"`
+}
+
+// AttendeeResponse representa un invitado de una reserva
+type AttendeeResponse struct {
+	ID          uint       `json:"id"`
+	UserID      *uint      `json:"user_id"` // Null si es un invitado externo
+	Email       string     `json:"email"`
+	Name        string     `json:"name"`
+	External    bool       `json:"external"`
+	RSVPStatus  string     `json:"rsvp_status"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
@@ -4,33 +4,106 @@ import "time"
 
 // CreateBookingRequest representa los datos para crear una reserva
 type CreateBookingRequest struct {
-	ResourceID    uint      `json:"resource_id" binding:"required"`
+	ResourceID    uint               `json:"resource_id" binding:"required"`
+	StartDatetime time.Time          `json:"start_datetime" binding:"required"`
+	EndDatetime   time.Time          `json:"end_datetime" binding:"required"`
+	Notes         string             `json:"notes"`
+	Seats         int                `json:"seats" binding:"omitempty,min=1"`                                       // Por defecto 1
+	Recurrence    *RecurrenceRequest `json:"recurrence"`                                                            // Opcional: crea una serie
+	ConflictMode  string             `json:"conflict_mode" binding:"omitempty,oneof=all_or_nothing skip_conflicts"` // Por defecto all_or_nothing
+}
+
+// RecurrenceRequest representa una regla de recurrencia al estilo RRULE
+type RecurrenceRequest struct {
+	Frequency string     `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	Interval  int        `json:"interval" binding:"omitempty,min=1"` // Por defecto 1
+	ByDay     []string   `json:"by_day"`                             // Ejemplo: ["MO", "WE"]
+	Count     int        `json:"count" binding:"omitempty,min=1"`    // Número de ocurrencias
+	Until     *time.Time `json:"until"`                              // Fecha límite (inclusive)
+}
+
+// UpdateBookingRequest representa los datos para actualizar una reserva
+type UpdateBookingRequest struct {
 	StartDatetime time.Time `json:"start_datetime" binding:"required"`
 	EndDatetime   time.Time `json:"end_datetime" binding:"required"`
 	Notes         string    `json:"notes"`
+	Seats         int       `json:"seats" binding:"omitempty,min=1"` // 0 conserva las plazas actuales
+	Reason        string    `json:"reason" binding:"max=500"`        // Motivo opcional que queda en el historial
 }
 
-// UpdateBookingRequest representa los datos para actualizar una reserva
-type UpdateBookingRequest struct {
+// EndBookingRequest representa el fin anticipado de una reserva en curso
+type EndBookingRequest struct {
+	Reason string `json:"reason" binding:"max=500"` // Motivo opcional que queda en el historial
+}
+
+// ExtendBookingRequest representa la ampliación de una reserva en curso
+type ExtendBookingRequest struct {
+	EndDatetime time.Time `json:"end_datetime" binding:"required"` // Nueva hora de fin, posterior a la actual
+	Reason      string    `json:"reason" binding:"max=500"`        // Motivo opcional que queda en el historial
+}
+
+// AdminCreateBookingRequest representa una reserva que un admin crea en nombre de otro usuario
+type AdminCreateBookingRequest struct {
+	UserID        uint      `json:"user_id" binding:"required"` // Titular de la reserva
+	ResourceID    uint      `json:"resource_id" binding:"required"`
 	StartDatetime time.Time `json:"start_datetime" binding:"required"`
 	EndDatetime   time.Time `json:"end_datetime" binding:"required"`
 	Notes         string    `json:"notes"`
+	Seats         int       `json:"seats" binding:"omitempty,min=1"` // Por defecto 1
+	Override      bool      `json:"override"`                        // Omite antelación, horizonte, cuotas y la restricción de horarios pasados
+	Reason        string    `json:"reason" binding:"max=500"`        // Obligatorio con override; queda en el historial
+}
+
+// AdminUpdateBookingRequest representa la modificación que un admin hace en nombre del titular
+type AdminUpdateBookingRequest struct {
+	UpdateBookingRequest
+	Override bool `json:"override"` // Omite antelación, horizonte, cuotas y la restricción de horarios pasados
+}
+
+// SkippedOccurrence representa una ocurrencia de la serie que no se pudo reservar
+type SkippedOccurrence struct {
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	Reason        string    `json:"reason"`
+}
+
+// BookingSeriesResponse representa el resultado de crear una serie de reservas
+type BookingSeriesResponse struct {
+	SeriesID uint                  `json:"series_id"`
+	Bookings []BookingListResponse `json:"bookings"`
+	Skipped  []SkippedOccurrence   `json:"skipped"`
 }
 
 // BookingResponse representa la respuesta de una reserva
 type BookingResponse struct {
-	ID            uint             `json:"id"`
-	UserID        uint             `json:"user_id"`
-	User          UserResponse     `json:"user"`
-	ResourceID    uint             `json:"resource_id"`
-	Resource      ResourceResponse `json:"resource"`
-	StartDatetime time.Time        `json:"start_datetime"`
-	EndDatetime   time.Time        `json:"end_datetime"`
-	Status        string           `json:"status"`
-	TotalPrice    float64          `json:"total_price"`
-	Notes         string           `json:"notes"`
-	CreatedAt     time.Time        `json:"created_at"`
-	UpdatedAt     time.Time        `json:"updated_at"`
+	ID            uint               `json:"id"`
+	UserID        uint               `json:"user_id"`
+	User          UserResponse       `json:"user"`
+	ResourceID    uint               `json:"resource_id"`
+	Resource      ResourceResponse   `json:"resource"`
+	StartDatetime time.Time          `json:"start_datetime"` // UTC
+	EndDatetime   time.Time          `json:"end_datetime"`   // UTC
+	Timezone      string             `json:"timezone"`       // Zona del recurso
+	StartLocal    time.Time          `json:"start_local"`    // Hora local del recurso con su desfase
+	EndLocal      time.Time          `json:"end_local"`
+	Status        string             `json:"status"`
+	Seats         int                `json:"seats"`
+	TotalPrice    float64            `json:"total_price"`
+	Notes         string             `json:"notes"`
+	SeriesID      *uint              `json:"series_id"`
+	ReservationID *uint              `json:"reservation_id"`
+	IsHold        bool               `json:"is_hold"`
+	ExpiresAt     *time.Time         `json:"expires_at"` // Null si no caduca
+	CancelReason  string             `json:"cancel_reason,omitempty"`
+	CheckedInAt   *time.Time         `json:"checked_in_at"`
+	DecidedBy     *uint              `json:"decided_by"` // Quién aprobó o rechazó la reserva
+	DecidedAt     *time.Time         `json:"decided_at"`
+	DecisionNote  string             `json:"decision_note,omitempty"`
+	CancelFee     float64            `json:"cancel_fee"`
+	RefundAmount  float64            `json:"refund_amount"`
+	Attendees     []AttendeeResponse `json:"attendees,omitempty"` // Solo en el detalle de la reserva
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
 }
 
 // BookingListResponse representa una reserva en la lista (más ligero)
@@ -40,16 +113,58 @@ type BookingListResponse struct {
 	UserName      string    `json:"user_name"`
 	ResourceID    uint      `json:"resource_id"`
 	ResourceName  string    `json:"resource_name"`
-	StartDatetime time.Time `json:"start_datetime"`
-	EndDatetime   time.Time `json:"end_datetime"`
+	StartDatetime time.Time `json:"start_datetime"` // UTC
+	EndDatetime   time.Time `json:"end_datetime"`   // UTC
+	Timezone      string    `json:"timezone"`
+	StartLocal    time.Time `json:"start_local"`
+	EndLocal      time.Time `json:"end_local"`
 	Status        string    `json:"status"`
+	Seats         int       `json:"seats"`
 	TotalPrice    float64   `json:"total_price"`
+	SeriesID      *uint     `json:"series_id"`
+	ReservationID *uint     `json:"reservation_id"`
+	RSVPStatus    string    `json:"rsvp_status,omitempty"` // Solo si el usuario asiste como invitado (solo lectura)
 	CreatedAt     time.Time `json:"created_at"`
 }
 
 // ChangeBookingStatusRequest representa el cambio de estado de una reserva
 type ChangeBookingStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending confirmed cancelled completed"`
+	Status   string `json:"status" binding:"required,oneof=pending confirmed cancelled completed no_show"`
+	WaiveFee bool   `json:"waive_fee"` // Al cancelar, no cobra el cargo de la política
+	Reason   string `json:"reason" binding:"max=500"`
+}
+
+// BookingDecisionRequest representa la aprobación o el rechazo de una reserva pendiente
+type BookingDecisionRequest struct {
+	Reason string `json:"reason" binding:"max=500"` // Obligatorio al rechazar
+}
+
+// CheckInRequest representa el registro de llegada con el código del QR
+type CheckInRequest struct {
+	Token string `json:"token"` // Obligatorio salvo para admin
+}
+
+// CheckInCodeResponse representa el código firmado de check-in de una reserva
+type CheckInCodeResponse struct {
+	BookingID       uint       `json:"booking_id"`
+	Token           string     `json:"token"`
+	QRPayload       string     `json:"qr_payload"` // Contenido a codificar en el QR
+	OpensAt         time.Time  `json:"opens_at"`
+	ClosesAt        time.Time  `json:"closes_at"` // Sin llegada a esta hora la reserva se libera si el recurso lo exige
+	RequiresCheckIn bool       `json:"requires_check_in"`
+	CheckedInAt     *time.Time `json:"checked_in_at"`
+}
+
+// CancellationPreview muestra lo que costaría cancelar una reserva ahora
+type CancellationPreview struct {
+	BookingID    uint    `json:"booking_id"`
+	Allowed      bool    `json:"allowed"`
+	Reason       string  `json:"reason,omitempty"` // Por qué no se puede cancelar
+	TotalPrice   float64 `json:"total_price"`
+	FeePercent   float64 `json:"fee_percent"`
+	CancelFee    float64 `json:"cancel_fee"`
+	RefundAmount float64 `json:"refund_amount"`
+	FeeWaived    bool    `json:"fee_waived"`
 }
 
 // BookingStatsResponse representa estadísticas de reservas
@@ -59,7 +174,18 @@ type BookingStatsResponse struct {
 	ConfirmedBookings int64   `json:"confirmed_bookings"`
 	CancelledBookings int64   `json:"cancelled_bookings"`
 	CompletedBookings int64   `json:"completed_bookings"`
+	NoShowBookings    int64   `json:"no_show_bookings"`
 	TotalRevenue      float64 `json:"total_revenue"`
 	PendingRevenue    float64 `json:"pending_revenue"`
 	ConfirmedRevenue  float64 `json:"confirmed_revenue"`
+	CancellationFees  float64 `json:"cancellation_fees"` // Cargos cobrados por cancelaciones
+}
+
+// HoldBookingRequest representa los datos para retener un horario mientras se completa el checkout
+type HoldBookingRequest struct {
+	ResourceID    uint      `json:"resource_id" binding:"required"`
+	StartDatetime time.Time `json:"start_datetime" binding:"required"`
+	EndDatetime   time.Time `json:"end_datetime" binding:"required"`
+	Seats         int       `json:"seats" binding:"omitempty,min=1"` // Por defecto 1
+	Notes         string    `json:"notes"`
 }
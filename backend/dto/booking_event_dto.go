@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// FieldChangeResponse representa el valor anterior y el nuevo de un campo de la reserva
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// BookingEventResponse representa un evento del historial de una reserva
+type BookingEventResponse struct {
+	ID        uint                  `json:"id"`
+	BookingID uint                  `json:"booking_id"`
+	Type      string                `json:"type"`
+	ActorID   *uint                 `json:"actor_id"` // Null si el cambio lo hizo el sistema
+	ActorName string                `json:"actor_name,omitempty"`
+	Changes   []FieldChangeResponse `json:"changes"`
+	Reason    string                `json:"reason,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// BookingPolicyRequest representa la política de un recurso, de una categoría o global.
+// Los campos omitidos se heredan del nivel más general.
+type BookingPolicyRequest struct {
+	ResourceID              *uint                 `json:"resource_id"`
+	Category                string                `json:"category"`
+	MinDurationMinutes      *int                  `json:"min_duration_minutes" binding:"omitempty,min=1"`
+	MaxDurationMinutes      *int                  `json:"max_duration_minutes" binding:"omitempty,min=1"`
+	MinNoticeMinutes        *int                  `json:"min_notice_minutes" binding:"omitempty,min=0"`
+	MaxDaysAhead            *int                  `json:"max_days_ahead" binding:"omitempty,min=1"`
+	StartGranularityMinutes *int                  `json:"start_granularity_minutes" binding:"omitempty,min=1,max=1440"`
+	CancelDeadlineMinutes   *int                  `json:"cancel_deadline_minutes" binding:"omitempty,min=0"`
+	CancellationFees        []CancellationFeeTier `json:"cancellation_fees" binding:"omitempty,dive"` // Omitido hereda; [] la deja gratuita
+}
+
+// CancellationFeeTier cobra fee_percent del precio si se cancela con menos de minutes_before minutos de antelación
+type CancellationFeeTier struct {
+	MinutesBefore int     `json:"minutes_before" binding:"required,min=1"`
+	FeePercent    float64 `json:"fee_percent" binding:"min=0,max=100"`
+}
+
+// BookingPolicyResponse representa una política guardada o la efectiva de un recurso
+type BookingPolicyResponse struct {
+	ID                      uint                  `json:"id,omitempty"`
+	Scope                   string                `json:"scope"` // resource, category, global o effective
+	ResourceID              *uint                 `json:"resource_id,omitempty"`
+	Category                string                `json:"category,omitempty"`
+	MinDurationMinutes      *int                  `json:"min_duration_minutes"`
+	MaxDurationMinutes      *int                  `json:"max_duration_minutes"`
+	MinNoticeMinutes        *int                  `json:"min_notice_minutes"`
+	MaxDaysAhead            *int                  `json:"max_days_ahead"`
+	StartGranularityMinutes *int                  `json:"start_granularity_minutes"`
+	CancelDeadlineMinutes   *int                  `json:"cancel_deadline_minutes"`
+	CancellationFees        []CancellationFeeTier `json:"cancellation_fees"`
+}
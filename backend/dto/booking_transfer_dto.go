@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// BookingTransferRequest representa la propuesta de pasar una reserva a otro usuario
+type BookingTransferRequest struct {
+	ToUserID uint   `json:"to_user_id" binding:"required"`
+	Message  string `json:"message" binding:"max=500"` // Opcional, lo ve el destinatario
+}
+
+// BookingTransferResponse representa una transferencia de reserva
+type BookingTransferResponse struct {
+	ID            uint       `json:"id"`
+	BookingID     uint       `json:"booking_id"`
+	ResourceName  string     `json:"resource_name"`
+	StartDatetime time.Time  `json:"start_datetime"` // UTC
+	EndDatetime   time.Time  `json:"end_datetime"`   // UTC
+	FromUserID    uint       `json:"from_user_id"`
+	FromUserName  string     `json:"from_user_name"`
+	ToUserID      uint       `json:"to_user_id"`
+	ToUserName    string     `json:"to_user_name"`
+	ProposedBy    uint       `json:"proposed_by"`
+	Status        string     `json:"status"`
+	Message       string     `json:"message,omitempty"`
+	RespondedAt   *time.Time `json:"responded_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// JobRunResponse representa una ejecución de una tarea programada
+type JobRunResponse struct {
+	ID         uint       `json:"id"`
+	Job        string     `json:"job"`
+	Instance   string     `json:"instance"`
+	Status     string     `json:"status"`
+	Processed  int        `json:"processed"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	DurationMs int64      `json:"duration_ms"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+// QuotaRequest representa la cuota de un usuario, de un rol o global, opcionalmente para una categoría.
+// Los límites omitidos se heredan del nivel más general.
+type QuotaRequest struct {
+	UserID            *uint    `json:"user_id"`
+	Role              string   `json:"role" binding:"omitempty,oneof=admin user"`
+	Category          string   `json:"category"`
+	MaxActiveBookings *int     `json:"max_active_bookings" binding:"omitempty,min=0"`
+	MaxHoursPerDay    *float64 `json:"max_hours_per_day" binding:"omitempty,min=0"`
+	MaxHoursPerWeek   *float64 `json:"max_hours_per_week" binding:"omitempty,min=0"`
+	MaxHoursPerMonth  *float64 `json:"max_hours_per_month" binding:"omitempty,min=0"`
+}
+
+// QuotaResponse representa una cuota configurada
+type QuotaResponse struct {
+	ID                uint     `json:"id"`
+	Scope             string   `json:"scope"` // user, role o global
+	UserID            *uint    `json:"user_id,omitempty"`
+	Role              string   `json:"role,omitempty"`
+	Category          string   `json:"category,omitempty"`
+	MaxActiveBookings *int     `json:"max_active_bookings"`
+	MaxHoursPerDay    *float64 `json:"max_hours_per_day"`
+	MaxHoursPerWeek   *float64 `json:"max_hours_per_week"`
+	MaxHoursPerMonth  *float64 `json:"max_hours_per_month"`
+}
+
+// QuotaUsage representa el uso de un límite concreto
+type QuotaUsage struct {
+	Rule      string  `json:"rule"`
+	Category  string  `json:"category,omitempty"` // Vacío: todas las categorías
+	Limit     float64 `json:"limit"`
+	Used      float64 `json:"used"`
+	Remaining float64 `json:"remaining"`
+}
+
+// QuotaUsageResponse representa el uso actual de las cuotas de un usuario
+type QuotaUsageResponse struct {
+	UserID uint         `json:"user_id"`
+	Role   string       `json:"role"`
+	Usage  []QuotaUsage `json:"usage"`
+}
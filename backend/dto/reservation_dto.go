@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// ReservationRequest representa una reserva de varios recursos que se confirma entera o no se crea.
+// El horario general se usa en los elementos que no indican el suyo.
+type ReservationRequest struct {
+	Title         string                   `json:"title" binding:"max=255"`
+	Notes         string                   `json:"notes"`
+	StartDatetime *time.Time               `json:"start_datetime"`
+	EndDatetime   *time.Time               `json:"end_datetime"`
+	Items         []ReservationItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReservationItemRequest representa uno de los recursos de una reserva múltiple
+type ReservationItemRequest struct {
+	ResourceID    uint       `json:"resource_id" binding:"required"`
+	StartDatetime *time.Time `json:"start_datetime"` // Por defecto el de la reserva
+	EndDatetime   *time.Time `json:"end_datetime"`
+	Seats         int        `json:"seats" binding:"omitempty,min=1"` // Por defecto 1
+}
+
+// ReservationResponse representa una reserva múltiple con sus reservas por recurso
+type ReservationResponse struct {
+	ID            uint                  `json:"id"`
+	UserID        uint                  `json:"user_id"`
+	Title         string                `json:"title"`
+	Notes         string                `json:"notes"`
+	Status        string                `json:"status"`      // Resumen del estado de las reservas
+	TotalPrice    float64               `json:"total_price"` // Suma de las reservas no canceladas
+	StartDatetime time.Time             `json:"start_datetime"`
+	EndDatetime   time.Time             `json:"end_datetime"`
+	Bookings      []BookingListResponse `json:"bookings"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
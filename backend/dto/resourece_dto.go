@@ -4,37 +4,78 @@ import "time"
 
 // CreateResourceRequest representa los datos para crear un recurso
 type CreateResourceRequest struct {
-	Name         string  `json:"name" binding:"required,min=3"`
-	Description  string  `json:"description"`
-	Capacity     int     `json:"capacity" binding:"required,min=1"`
-	PricePerHour float64 `json:"price_per_hour" binding:"required,min=0"`
-	Category     string  `json:"category"`
-	ImageURL     string  `json:"image_url"`
+	Name            string  `json:"name" binding:"required,min=3"`
+	Description     string  `json:"description"`
+	Capacity        int     `json:"capacity" binding:"required,min=1"`
+	PricePerHour    float64 `json:"price_per_hour" binding:"required,min=0"`
+	Category        string  `json:"category"`
+	ImageURL        string  `json:"image_url"`
+	BookingMode     string  `json:"booking_mode" binding:"omitempty,oneof=exclusive shared"` // Por defecto exclusive
+	PricePerSeat    bool    `json:"price_per_seat"`
+	Timezone        string  `json:"timezone"`                      // Zona IANA, por defecto UTC
+	BufferBefore    int     `json:"buffer_before" binding:"min=0"` // Minutos
+	BufferAfter     int     `json:"buffer_after" binding:"min=0"`  // Minutos
+	HoldTTL         int     `json:"hold_ttl" binding:"min=0"`      // Minutos que una reserva puede seguir pendiente, 0 = sin límite
+	RequiresCheckIn bool    `json:"requires_check_in"`
+	CheckInBefore   *int    `json:"check_in_before" binding:"omitempty,min=0"`                    // Minutos, por defecto 15
+	CheckInGrace    *int    `json:"check_in_grace" binding:"omitempty,min=0"`                     // Minutos, por defecto 15
+	ApprovalMode    string  `json:"approval_mode" binding:"omitempty,oneof=auto admin approvers"` // Por defecto admin
 }
 
 // UpdateResourceRequest representa los datos para actualizar un recurso
 type UpdateResourceRequest struct {
-	Name         string  `json:"name" binding:"omitempty,min=3"`
-	Description  string  `json:"description"`
-	Capacity     int     `json:"capacity" binding:"omitempty,min=1"`
-	PricePerHour float64 `json:"price_per_hour" binding:"omitempty,min=0"`
-	Category     string  `json:"category"`
-	ImageURL     string  `json:"image_url"`
-	IsActive     *bool   `json:"is_active"` // Pointer para permitir false
+	Name            string  `json:"name" binding:"omitempty,min=3"`
+	Description     string  `json:"description"`
+	Capacity        int     `json:"capacity" binding:"omitempty,min=1"`
+	PricePerHour    float64 `json:"price_per_hour" binding:"omitempty,min=0"`
+	Category        string  `json:"category"`
+	ImageURL        string  `json:"image_url"`
+	IsActive        *bool   `json:"is_active"` // Pointer para permitir false
+	BookingMode     string  `json:"booking_mode" binding:"omitempty,oneof=exclusive shared"`
+	PricePerSeat    *bool   `json:"price_per_seat"`
+	Timezone        string  `json:"timezone"`
+	BufferBefore    *int    `json:"buffer_before" binding:"omitempty,min=0"`
+	BufferAfter     *int    `json:"buffer_after" binding:"omitempty,min=0"`
+	HoldTTL         *int    `json:"hold_ttl" binding:"omitempty,min=0"`
+	RequiresCheckIn *bool   `json:"requires_check_in"`
+	CheckInBefore   *int    `json:"check_in_before" binding:"omitempty,min=0"`
+	CheckInGrace    *int    `json:"check_in_grace" binding:"omitempty,min=0"`
+	ApprovalMode    string  `json:"approval_mode" binding:"omitempty,oneof=auto admin approvers"`
+}
+
+// CategoryBuffersRequest representa los márgenes a aplicar a todos los recursos de una categoría
+type CategoryBuffersRequest struct {
+	BufferBefore *int `json:"buffer_before" binding:"required,min=0"` // Minutos
+	BufferAfter  *int `json:"buffer_after" binding:"required,min=0"`  // Minutos
 }
 
 // ResourceResponse representa la respuesta de un recurso
 type ResourceResponse struct {
-	ID           uint      `json:"id"`
-	Name         string    `json:"name"`
-	Description  string    `json:"description"`
-	Capacity     int       `json:"capacity"`
-	PricePerHour float64   `json:"price_per_hour"`
-	Category     string    `json:"category"`
-	ImageURL     string    `json:"image_url"`
-	IsActive     bool      `json:"is_active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Capacity        int       `json:"capacity"`
+	PricePerHour    float64   `json:"price_per_hour"`
+	Category        string    `json:"category"`
+	ImageURL        string    `json:"image_url"`
+	IsActive        bool      `json:"is_active"`
+	BookingMode     string    `json:"booking_mode"`
+	PricePerSeat    bool      `json:"price_per_seat"`
+	Timezone        string    `json:"timezone"`
+	BufferBefore    int       `json:"buffer_before"`
+	BufferAfter     int       `json:"buffer_after"`
+	HoldTTL         int       `json:"hold_ttl"`
+	RequiresCheckIn bool      `json:"requires_check_in"`
+	CheckInBefore   int       `json:"check_in_before"`
+	CheckInGrace    int       `json:"check_in_grace"`
+	ApprovalMode    string    `json:"approval_mode"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ResourceApproversRequest representa los usuarios designados para aprobar las reservas de un recurso
+type ResourceApproversRequest struct {
+	UserIDs []uint `json:"user_ids" binding:"required"` // Lista vacía para quitar a todos
 }
 
 // ResourceListResponse representa un recurso en la lista (más ligero)
@@ -46,4 +87,6 @@ type ResourceListResponse struct {
 	Category     string  `json:"category"`
 	ImageURL     string  `json:"image_url"`
 	IsActive     bool    `json:"is_active"`
+	BookingMode  string  `json:"booking_mode"`
+	Timezone     string  `json:"timezone"`
 }
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// JoinWaitlistRequest representa los datos para apuntarse a la lista de espera de un horario ocupado
+type JoinWaitlistRequest struct {
+	ResourceID    uint      `json:"resource_id" binding:"required"`
+	StartDatetime time.Time `json:"start_datetime" binding:"required"`
+	EndDatetime   time.Time `json:"end_datetime" binding:"required"`
+	Seats         int       `json:"seats" binding:"omitempty,min=1"` // Por defecto 1
+	Notes         string    `json:"notes"`
+}
+
+// ReorderWaitlistRequest representa el nuevo orden de la lista de espera de un recurso
+type ReorderWaitlistRequest struct {
+	EntryIDs []uint `json:"entry_ids" binding:"required,min=1"` // Todas las entradas en espera, de la primera a la última
+}
+
+// WaitlistEntryResponse representa la respuesta de una entrada de la lista de espera
+type WaitlistEntryResponse struct {
+	ID            uint      `json:"id"`
+	UserID        uint      `json:"user_id"`
+	UserName      string    `json:"user_name,omitempty"`
+	ResourceID    uint      `json:"resource_id"`
+	ResourceName  string    `json:"resource_name,omitempty"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	Seats         int       `json:"seats"`
+	Notes         string    `json:"notes"`
+	Position      int       `json:"position"`
+	Status        string    `json:"status"`
+	BookingID     *uint     `json:"booking_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"Reservify/repositories"
+	"Reservify/services"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Nombres de las tareas programadas, tal como aparecen en /api/admin/jobs
+const (
+	JobExpirePendingBookings = "expire_pending_bookings"
+	JobCompleteBookings      = "complete_bookings"
+	JobReleaseUnclaimed      = "release_unclaimed_bookings"
+)
+
+// Start registra las tareas programadas y arranca el scheduler en segundo plano
+func Start(db *gorm.DB) {
+	// Inicializar repositorios
+	bookingRepo := repositories.NewBookingRepository(db)
+	resourceRepo := repositories.NewResourceRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	availabilityRepo := repositories.NewAvailabilityRepository(db)
+	blackoutRepo := repositories.NewBlackoutRepository(db)
+	policyRepo := repositories.NewBookingPolicyRepository(db)
+	quotaRepo := repositories.NewQuotaRepository(db)
+	waitlistRepo := repositories.NewWaitlistRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	jobRepo := repositories.NewJobRepository(db)
+
+	// Inicializar servicios
+	bookingService := services.NewBookingService(bookingRepo, resourceRepo, userRepo, availabilityRepo, blackoutRepo, policyRepo, quotaRepo, waitlistRepo, notificationRepo)
+
+	scheduler := NewScheduler(jobRepo)
+	scheduler.Register(Job{
+		Name:     JobExpirePendingBookings,
+		Interval: time.Minute,
+		Run:      bookingService.ExpirePendingBookings,
+	})
+	scheduler.Register(Job{
+		Name:     JobCompleteBookings,
+		Interval: 5 * time.Minute,
+		Run:      bookingService.CompleteFinishedBookings,
+	})
+	scheduler.Register(Job{
+		Name:     JobReleaseUnclaimed,
+		Interval: time.Minute,
+		Run:      bookingService.ReleaseUnclaimedBookings,
+	})
+	scheduler.Start()
+}
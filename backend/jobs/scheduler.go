@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"Reservify/models"
+	"Reservify/repositories"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Job es una tarea periódica; Run devuelve cuántos elementos procesó
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() (int, error)
+}
+
+// Scheduler ejecuta tareas periódicas dentro del proceso de la API. Antes de cada ejecución toma
+// un lease en base de datos, así con varias instancias cada tarea corre en una sola a la vez.
+type Scheduler struct {
+	jobRepo  *repositories.JobRepository
+	instance string
+	jobs     []Job
+}
+
+func NewScheduler(jobRepo *repositories.JobRepository) *Scheduler {
+	return &Scheduler{jobRepo: jobRepo, instance: instanceName()}
+}
+
+// Register agrega una tarea al scheduler
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start lanza cada tarea registrada en su propia goroutine
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		go s.loop(job)
+	}
+	log.Printf(" Scheduler iniciado en %s con %d tareas", s.instance, len(s.jobs))
+}
+
+// loop ejecuta la tarea en cada intervalo
+func (s *Scheduler) loop(job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.runOnce(job)
+	}
+}
+
+// runOnce ejecuta la tarea si esta instancia consigue el lease y registra el resultado.
+// Quien ya tiene el lease lo renueva; las demás instancias esperan a que venza.
+func (s *Scheduler) runOnce(job Job) {
+	now := time.Now()
+	acquired, err := s.jobRepo.AcquireLease(job.Name, s.instance, job.Interval, now)
+	if err != nil {
+		log.Printf(" Error al tomar la tarea %s: %v", job.Name, err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	run := &models.JobRun{
+		Job:       job.Name,
+		Instance:  s.instance,
+		Status:    models.JobRunRunning,
+		StartedAt: now.UTC(),
+	}
+	if err := s.jobRepo.CreateRun(run); err != nil {
+		log.Printf(" Error al registrar la tarea %s: %v", job.Name, err)
+		return
+	}
+
+	processed, err := safeRun(job)
+
+	finishedAt := time.Now().UTC()
+	run.Processed = processed
+	run.FinishedAt = &finishedAt
+	run.Status = models.JobRunSuccess
+	if err != nil {
+		run.Status = models.JobRunFailed
+		run.Error = err.Error()
+		log.Printf(" Error en la tarea %s: %v", job.Name, err)
+	}
+
+	if err := s.jobRepo.UpdateRun(run); err != nil {
+		log.Printf(" Error al guardar el resultado de la tarea %s: %v", job.Name, err)
+	}
+}
+
+// safeRun evita que un panic en una tarea detenga su goroutine
+func safeRun(job Job) (processed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run()
+}
+
+// instanceName identifica a esta instancia de la API en los leases y las ejecuciones
+func instanceName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "desconocido"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
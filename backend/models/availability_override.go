@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AvailabilityOverride reemplaza la plantilla semanal de un recurso en una fecha concreta.
+// Una fecha puede tener varias franjas; si tiene alguna, se ignoran los horarios semanales de ese día.
+type AvailabilityOverride struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	ResourceID uint           `gorm:"not null;index:idx_override_resource_date" json:"resource_id"`
+	Date       string         `gorm:"size:10;not null;index:idx_override_resource_date" json:"date"` // Formato: "2025-06-14"
+	StartTime  string         `gorm:"type:time;not null" json:"start_time"`                          // Formato: "10:00:00"
+	EndTime    string         `gorm:"type:time;not null" json:"end_time"`                            // Formato: "14:00:00"
+	Reason     string         `gorm:"size:255" json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	Resource Resource `gorm:"foreignKey:ResourceID" json:"resource,omitempty"`
+}
+
+func (AvailabilityOverride) TableName() string {
+	return "availability_overrides"
+}
@@ -18,6 +18,20 @@ const (
 	Sunday    DayOfWeek = "sunday"
 )
 
+// DayOfWeekFromWeekday convierte un time.Weekday al valor usado en los horarios
+func DayOfWeekFromWeekday(weekday time.Weekday) DayOfWeek {
+	days := map[time.Weekday]DayOfWeek{
+		time.Monday:    Monday,
+		time.Tuesday:   Tuesday,
+		time.Wednesday: Wednesday,
+		time.Thursday:  Thursday,
+		time.Friday:    Friday,
+		time.Saturday:  Saturday,
+		time.Sunday:    Sunday,
+	}
+	return days[weekday]
+}
+
 type AvailabilitySlot struct {
 	ID         uint           `gorm:"primaryKey" json:"id"`
 	ResourceID uint           `gorm:"not null;index" json:"resource_id"`
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Blackout representa un cierre puntual (feriado, evento privado) que anula la disponibilidad semanal.
+// Sin ResourceID ni Category aplica a todos los recursos.
+type Blackout struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	ResourceID    *uint          `gorm:"index" json:"resource_id"`
+	Category      string         `gorm:"size:100;index" json:"category"`
+	AllDay        bool           `gorm:"default:false" json:"all_day"`
+	StartDate     string         `gorm:"size:10" json:"start_date"` // Formato: "2025-12-25" (solo si AllDay)
+	EndDate       string         `gorm:"size:10" json:"end_date"`   // Inclusive
+	StartDatetime *time.Time     `json:"start_datetime"`            // Solo si no es AllDay
+	EndDatetime   *time.Time     `json:"end_datetime"`
+	Reason        string         `gorm:"size:255;not null" json:"reason"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	Resource *Resource `gorm:"foreignKey:ResourceID" json:"resource,omitempty"`
+}
+
+func (Blackout) TableName() string {
+	return "blackouts"
+}
+
+// AppliesTo indica si el cierre afecta al recurso
+func (b *Blackout) AppliesTo(resource *Resource) bool {
+	if b.ResourceID != nil {
+		return *b.ResourceID == resource.ID
+	}
+	if b.Category != "" {
+		return b.Category == resource.Category
+	}
+	return true
+}
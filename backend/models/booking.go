@@ -13,6 +13,7 @@ const (
 	StatusConfirmed BookingStatus = "confirmed"
 	StatusCancelled BookingStatus = "cancelled"
 	StatusCompleted BookingStatus = "completed"
+	StatusNoShow    BookingStatus = "no_show" // Terminó sin el check-in que exigía el recurso
 )
 
 type Booking struct {
@@ -22,8 +23,20 @@ type Booking struct {
 	StartDatetime time.Time      `gorm:"not null;index:idx_resource_datetime" json:"start_datetime"`
 	EndDatetime   time.Time      `gorm:"not null;index:idx_resource_datetime" json:"end_datetime"`
 	Status        BookingStatus  `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	Seats         int            `gorm:"not null;default:1" json:"seats"`
 	TotalPrice    float64        `gorm:"type:decimal(10,2)" json:"total_price"`
 	Notes         string         `gorm:"type:text" json:"notes"`
+	SeriesID      *uint          `gorm:"index" json:"series_id"`        // Null si no es recurrente
+	ReservationID *uint          `gorm:"index" json:"reservation_id"`   // Null si no forma parte de una reserva múltiple
+	IsHold        bool           `gorm:"default:false" json:"is_hold"`  // Retención temporal mientras el usuario completa el checkout
+	ExpiresAt     *time.Time     `gorm:"index" json:"expires_at"`       // Se cancela si sigue pendiente a esta hora
+	CancelReason  string         `gorm:"size:255" json:"cancel_reason"` // Motivo de las cancelaciones automáticas
+	CheckedInAt   *time.Time     `json:"checked_in_at"`                 // Llegada registrada del usuario
+	DecidedBy     *uint          `json:"decided_by"`                    // Quién aprobó o rechazó la reserva
+	DecidedAt     *time.Time     `json:"decided_at"`
+	DecisionNote  string         `gorm:"size:500" json:"decision_note"` // Motivo de la aprobación o el rechazo
+	CancelFee     float64        `gorm:"type:decimal(10,2);default:0" json:"cancel_fee"`
+	RefundAmount  float64        `gorm:"type:decimal(10,2);default:0" json:"refund_amount"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+type RSVPStatus string
+
+const (
+	RSVPPending   RSVPStatus = "pending"
+	RSVPAccepted  RSVPStatus = "accepted"
+	RSVPTentative RSVPStatus = "tentative"
+	RSVPDeclined  RSVPStatus = "declined"
+)
+
+// BookingAttendee es una persona invitada a una reserva además de su titular:
+// un usuario registrado (UserID) o un invitado externo identificado por su email
+type BookingAttendee struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	BookingID   uint       `gorm:"not null;index" json:"booking_id"`
+	UserID      *uint      `gorm:"index" json:"user_id"`  // Null si es un invitado externo
+	Email       string     `gorm:"size:255" json:"email"` // Solo invitados externos
+	Name        string     `gorm:"size:255" json:"name"`
+	RSVPStatus  RSVPStatus `gorm:"type:varchar(20);default:'pending'" json:"rsvp_status"`
+	RespondedAt *time.Time `json:"responded_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relaciones
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (BookingAttendee) TableName() string {
+	return "booking_attendees"
+}
+
+// IsExternal indica si el invitado no tiene cuenta en el sistema
+func (a *BookingAttendee) IsExternal() bool {
+	return a.UserID == nil
+}
+
+// Respond registra la respuesta del invitado
+func (a *BookingAttendee) Respond(status RSVPStatus, now time.Time) {
+	a.RSVPStatus = status
+	respondedAt := now.UTC()
+	a.RespondedAt = &respondedAt
+}
+
+// Headcount cuenta las personas que ocuparán el recurso: el titular más los invitados que no rechazaron
+func Headcount(attendees []BookingAttendee) int {
+	count := 1
+	for _, attendee := range attendees {
+		if attendee.RSVPStatus != RSVPDeclined {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+type BookingEventType string
+
+const (
+	EventCreated       BookingEventType = "created"
+	EventStatusChanged BookingEventType = "status_changed"
+	EventModified      BookingEventType = "modified" // Horario, plazas, notas u otros datos sin cambio de estado
+)
+
+// BookingEvent registra un cambio de una reserva: quién lo hizo, cuándo, qué cambió y por qué
+type BookingEvent struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	BookingID uint             `gorm:"not null;index" json:"booking_id"`
+	ActorID   *uint            `gorm:"index" json:"actor_id"` // Null si el cambio lo hizo el sistema
+	Type      BookingEventType `gorm:"type:varchar(20);not null" json:"type"`
+	Changes   FieldChanges     `gorm:"type:json" json:"changes"`
+	Reason    string           `gorm:"size:500" json:"reason"`
+	CreatedAt time.Time        `gorm:"index" json:"created_at"`
+
+	// Relaciones
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+func (BookingEvent) TableName() string {
+	return "booking_events"
+}
+
+// FieldChange es el valor anterior y el nuevo de un campo de la reserva
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// FieldChanges son los campos modificados en un evento; se guardan como JSON
+type FieldChanges []FieldChange
+
+// Value serializa los cambios a JSON
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan lee los cambios guardados como JSON
+func (c *FieldChanges) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("formato inválido para los cambios de la reserva")
+	}
+	return json.Unmarshal(data, c)
+}
+
+// NewBookingEvent arma el evento con las diferencias entre before y after.
+// before nil indica que la reserva se acaba de crear. Devuelve nil si no cambió nada.
+func NewBookingEvent(before, after *Booking, actorID *uint, reason string) *BookingEvent {
+	eventType := EventCreated
+	if before == nil {
+		before = &Booking{}
+	} else if before.Status != after.Status {
+		eventType = EventStatusChanged
+	} else {
+		eventType = EventModified
+	}
+
+	changes := DiffBooking(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return &BookingEvent{
+		BookingID: after.ID,
+		ActorID:   actorID,
+		Type:      eventType,
+		Changes:   changes,
+		Reason:    reason,
+	}
+}
+
+// DiffBooking devuelve los campos relevantes para el historial que difieren entre dos versiones de una reserva
+func DiffBooking(before, after *Booking) FieldChanges {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"status", string(before.Status), string(after.Status)},
+		{"user_id", formatID(before.UserID), formatID(after.UserID)},
+		{"start_datetime", formatEventTime(before.StartDatetime), formatEventTime(after.StartDatetime)},
+		{"end_datetime", formatEventTime(before.EndDatetime), formatEventTime(after.EndDatetime)},
+		{"seats", formatID(uint(before.Seats)), formatID(uint(after.Seats))},
+		{"total_price", formatAmount(before.TotalPrice), formatAmount(after.TotalPrice)},
+		{"notes", before.Notes, after.Notes},
+		{"checked_in_at", formatEventTimePtr(before.CheckedInAt), formatEventTimePtr(after.CheckedInAt)},
+	}
+
+	var changes FieldChanges
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
+
+// formatID formatea un entero sin signo; el cero se muestra vacío
+func formatID(value uint) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+// formatAmount formatea un importe con dos decimales
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// formatEventTime formatea un instante en UTC; el valor cero se muestra vacío
+func formatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatEventTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatEventTime(*t)
+}
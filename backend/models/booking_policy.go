@@ -0,0 +1,119 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookingPolicy define las reglas de reserva de un recurso, de una categoría o globales.
+// Los campos nulos se heredan de la política más general (recurso → categoría → global).
+type BookingPolicy struct {
+	ID                      uint           `gorm:"primaryKey" json:"id"`
+	ResourceID              *uint          `gorm:"index" json:"resource_id"`
+	Category                string         `gorm:"size:100;index" json:"category"`
+	MinDurationMinutes      *int           `json:"min_duration_minutes"`
+	MaxDurationMinutes      *int           `json:"max_duration_minutes"`
+	MinNoticeMinutes        *int           `json:"min_notice_minutes"`                 // Antelación mínima antes del inicio
+	MaxDaysAhead            *int           `json:"max_days_ahead"`                     // Horizonte máximo de reserva
+	StartGranularityMinutes *int           `json:"start_granularity_minutes"`          // p. ej. 30 permite :00 y :30
+	CancelDeadlineMinutes   *int           `json:"cancel_deadline_minutes"`            // Minutos antes del inicio en que ya no se puede cancelar
+	CancellationFees        FeeTiers       `gorm:"type:json" json:"cancellation_fees"` // Null hereda; vacío significa cancelación gratuita
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	Resource *Resource `gorm:"foreignKey:ResourceID" json:"resource,omitempty"`
+}
+
+func (BookingPolicy) TableName() string {
+	return "booking_policies"
+}
+
+// Scope indica el nivel de la política: "resource", "category" o "global"
+func (p *BookingPolicy) Scope() string {
+	switch {
+	case p.ResourceID != nil:
+		return "resource"
+	case p.Category != "":
+		return "category"
+	default:
+		return "global"
+	}
+}
+
+// Inherit completa los campos nulos con los de una política más general
+func (p BookingPolicy) Inherit(parent BookingPolicy) BookingPolicy {
+	pick := func(own, inherited *int) *int {
+		if own != nil {
+			return own
+		}
+		return inherited
+	}
+
+	p.MinDurationMinutes = pick(p.MinDurationMinutes, parent.MinDurationMinutes)
+	p.MaxDurationMinutes = pick(p.MaxDurationMinutes, parent.MaxDurationMinutes)
+	p.MinNoticeMinutes = pick(p.MinNoticeMinutes, parent.MinNoticeMinutes)
+	p.MaxDaysAhead = pick(p.MaxDaysAhead, parent.MaxDaysAhead)
+	p.StartGranularityMinutes = pick(p.StartGranularityMinutes, parent.StartGranularityMinutes)
+	p.CancelDeadlineMinutes = pick(p.CancelDeadlineMinutes, parent.CancelDeadlineMinutes)
+	if p.CancellationFees == nil {
+		p.CancellationFees = parent.CancellationFees
+	}
+	return p
+}
+
+// FeeTier cobra FeePercent del precio si se cancela con menos de MinutesBefore minutos de antelación
+type FeeTier struct {
+	MinutesBefore int     `json:"minutes_before"`
+	FeePercent    float64 `json:"fee_percent"`
+}
+
+// FeeTiers son los tramos de cargo por cancelación; se guardan como JSON.
+// Ejemplo: [{2880, 50}, {1440, 100}] es gratis hasta 48 h antes, 50 % hasta 24 h y sin reembolso después.
+type FeeTiers []FeeTier
+
+// FeePercent devuelve el porcentaje a cobrar al cancelar con la antelación indicada: el del tramo
+// más estricto que aplique, o 0 si ninguno aplica
+func (t FeeTiers) FeePercent(notice time.Duration) float64 {
+	percent := 0.0
+	for _, tier := range t {
+		if notice < time.Duration(tier.MinutesBefore)*time.Minute && tier.FeePercent > percent {
+			percent = tier.FeePercent
+		}
+	}
+	return percent
+}
+
+// Value serializa los tramos a JSON (nil se guarda como NULL para heredar)
+func (t FeeTiers) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan lee los tramos guardados como JSON
+func (t *FeeTiers) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("formato inválido para los tramos de cancelación")
+	}
+	return json.Unmarshal(data, t)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookingQuota limita cuánto puede reservar un usuario. Puede definirse para un usuario concreto,
+// para un rol o de forma global; con Category solo cuenta las reservas de esa categoría.
+// Los campos nulos se heredan del nivel más general (usuario → rol → global) de la misma categoría.
+type BookingQuota struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	UserID            *uint          `gorm:"index" json:"user_id"`
+	Role              UserRole       `gorm:"type:varchar(20);index" json:"role"`
+	Category          string         `gorm:"size:100;index" json:"category"` // Vacío: todas las categorías
+	MaxActiveBookings *int           `json:"max_active_bookings"`            // Reservas pending/confirmed a la vez
+	MaxHoursPerDay    *float64       `json:"max_hours_per_day"`
+	MaxHoursPerWeek   *float64       `json:"max_hours_per_week"`
+	MaxHoursPerMonth  *float64       `json:"max_hours_per_month"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (BookingQuota) TableName() string {
+	return "booking_quotas"
+}
+
+// Scope indica a quién se aplica la cuota: "user", "role" o "global"
+func (q *BookingQuota) Scope() string {
+	switch {
+	case q.UserID != nil:
+		return "user"
+	case q.Role != "":
+		return "role"
+	default:
+		return "global"
+	}
+}
+
+// Inherit completa los límites nulos con los de una cuota más general
+func (q BookingQuota) Inherit(parent BookingQuota) BookingQuota {
+	if q.MaxActiveBookings == nil {
+		q.MaxActiveBookings = parent.MaxActiveBookings
+	}
+	if q.MaxHoursPerDay == nil {
+		q.MaxHoursPerDay = parent.MaxHoursPerDay
+	}
+	if q.MaxHoursPerWeek == nil {
+		q.MaxHoursPerWeek = parent.MaxHoursPerWeek
+	}
+	if q.MaxHoursPerMonth == nil {
+		q.MaxHoursPerMonth = parent.MaxHoursPerMonth
+	}
+	return q
+}
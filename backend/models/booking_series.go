@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookingSeries agrupa las ocurrencias generadas por una regla de recurrencia
+type BookingSeries struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	UserID     uint           `gorm:"not null;index" json:"user_id"`
+	ResourceID uint           `gorm:"not null;index" json:"resource_id"`
+	Frequency  string         `gorm:"type:varchar(20);not null" json:"frequency"` // daily, weekly, monthly
+	Interval   int            `gorm:"not null;default:1" json:"interval"`
+	ByDay      string         `gorm:"size:50" json:"by_day"` // Formato: "MO,WE,FR"
+	Count      int            `json:"count"`
+	Until      *time.Time     `json:"until"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	Bookings []Booking `gorm:"foreignKey:SeriesID" json:"bookings,omitempty"`
+}
+
+func (BookingSeries) TableName() string {
+	return "booking_series"
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferAccepted  TransferStatus = "accepted"
+	TransferDeclined  TransferStatus = "declined"
+	TransferCancelled TransferStatus = "cancelled" // Retirada por quien la propuso o la reserva dejó de estar activa
+)
+
+// BookingTransfer es una propuesta para pasar una reserva de su titular a otro usuario,
+// que debe aceptarla para que cambie el titular
+type BookingTransfer struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	BookingID   uint           `gorm:"not null;index" json:"booking_id"`
+	FromUserID  uint           `gorm:"not null;index" json:"from_user_id"` // Titular al proponerla
+	ToUserID    uint           `gorm:"not null;index" json:"to_user_id"`
+	ProposedBy  uint           `gorm:"not null" json:"proposed_by"` // El titular o un admin
+	Status      TransferStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	Message     string         `gorm:"size:500" json:"message"`
+	RespondedAt *time.Time     `json:"responded_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+
+	// Relaciones
+	Booking  Booking `gorm:"foreignKey:BookingID" json:"booking,omitempty"`
+	FromUser User    `gorm:"foreignKey:FromUserID" json:"from_user,omitempty"`
+	ToUser   User    `gorm:"foreignKey:ToUserID" json:"to_user,omitempty"`
+}
+
+func (BookingTransfer) TableName() string {
+	return "booking_transfers"
+}
+
+// IsPending indica si la transferencia sigue esperando la respuesta del destinatario
+func (t *BookingTransfer) IsPending() bool {
+	return t.Status == TransferPending
+}
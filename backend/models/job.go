@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type JobRunStatus string
+
+const (
+	JobRunRunning JobRunStatus = "running"
+	JobRunSuccess JobRunStatus = "success"
+	JobRunFailed  JobRunStatus = "failed"
+)
+
+// JobLease garantiza que una tarea programada corra en una sola instancia de la API a la vez
+type JobLease struct {
+	Name        string    `gorm:"primaryKey;size:100" json:"name"`
+	Holder      string    `gorm:"size:255" json:"holder"` // Instancia que tiene la tarea
+	LockedUntil time.Time `gorm:"not null" json:"locked_until"`
+}
+
+func (JobLease) TableName() string {
+	return "job_leases"
+}
+
+// JobRun registra una ejecución de una tarea programada
+type JobRun struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	Job        string       `gorm:"size:100;not null;index" json:"job"`
+	Instance   string       `gorm:"size:255" json:"instance"`
+	Status     JobRunStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Processed  int          `gorm:"not null;default:0" json:"processed"` // Elementos afectados
+	Error      string       `gorm:"type:text" json:"error"`
+	StartedAt  time.Time    `gorm:"not null;index" json:"started_at"`
+	FinishedAt *time.Time   `json:"finished_at"`
+}
+
+func (JobRun) TableName() string {
+	return "job_runs"
+}
@@ -15,8 +15,20 @@ func AutoMigrate(db *gorm.DB) error {
 		&User{},
 		&Resource{},
 		&AvailabilitySlot{},
+		&AvailabilityOverride{},
+		&BookingSeries{},
+		&Reservation{},
 		&Booking{},
+		&BookingEvent{},
+		&BookingAttendee{},
+		&BookingTransfer{},
 		&Notification{},
+		&Blackout{},
+		&BookingPolicy{},
+		&BookingQuota{},
+		&WaitlistEntry{},
+		&JobLease{},
+		&JobRun{},
 	)
 	if err != nil {
 		return fmt.Errorf("Error en auto-migrate: %v", err)
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Reservation agrupa reservas de varios recursos que se crean, modifican y cancelan juntas
+type Reservation struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	Title     string         `gorm:"size:255" json:"title"`
+	Notes     string         `gorm:"type:text" json:"notes"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	User     User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Bookings []Booking `gorm:"foreignKey:ReservationID" json:"bookings,omitempty"`
+}
+
+func (Reservation) TableName() string {
+	return "reservations"
+}
+
+// TotalPrice suma el precio de las reservas que siguen activas o ya se usaron
+func (r *Reservation) TotalPrice() float64 {
+	total := 0.0
+	for _, booking := range r.Bookings {
+		if booking.Status != StatusCancelled {
+			total += booking.TotalPrice
+		}
+	}
+	return total
+}
+
+// Status resume el estado del conjunto: cancelled si todas están canceladas, confirmed si
+// todas las activas están confirmadas (o ya terminaron) y pending en otro caso
+func (r *Reservation) Status() BookingStatus {
+	status := StatusCancelled
+	for _, booking := range r.Bookings {
+		switch booking.Status {
+		case StatusCancelled:
+			continue
+		case StatusPending:
+			return StatusPending
+		default:
+			status = StatusConfirmed
+		}
+	}
+	return status
+}
@@ -6,6 +6,22 @@ import (
 	"gorm.io/gorm"
 )
 
+type BookingMode string
+
+const (
+	BookingModeExclusive BookingMode = "exclusive" // Una reserva a la vez
+	BookingModeShared    BookingMode = "shared"    // Varias reservas hasta completar la capacidad
+)
+
+// ApprovalMode indica quién debe aprobar las reservas del recurso
+type ApprovalMode string
+
+const (
+	ApprovalAuto      ApprovalMode = "auto"      // Las reservas se confirman al crearse
+	ApprovalAdmin     ApprovalMode = "admin"     // Un administrador aprueba cada reserva
+	ApprovalApprovers ApprovalMode = "approvers" // Los aprobadores designados del recurso (o un admin) aprueban
+)
+
 type Resource struct {
 	ID                uint               `gorm:"primaryKey" json:"id"`
 	Name              string             `gorm:"not null;size:255" json:"name"`
@@ -15,13 +31,75 @@ type Resource struct {
 	Category          string             `gorm:"size:100" json:"category"`
 	ImageURL          string             `gorm:"size:500" json:"image_url"`
 	IsActive          bool               `gorm:"default:true" json:"is_active"`
+	BookingMode       BookingMode        `gorm:"type:varchar(20);default:'exclusive'" json:"booking_mode"`
+	PricePerSeat      bool               `gorm:"default:false" json:"price_per_seat"`            // Si es true el precio se multiplica por plazas
+	Timezone          string             `gorm:"size:64;not null;default:'UTC'" json:"timezone"` // Zona IANA, p. ej. "America/Bogota"
+	BufferBefore      int                `gorm:"not null;default:0" json:"buffer_before"`        // Minutos de preparación antes de cada reserva
+	BufferAfter       int                `gorm:"not null;default:0" json:"buffer_after"`         // Minutos de limpieza después de cada reserva
+	HoldTTL           int                `gorm:"not null;default:0" json:"hold_ttl"`             // Minutos que una reserva puede seguir pendiente (0 = sin límite)
+	RequiresCheckIn   bool               `gorm:"default:false" json:"requires_check_in"`         // Sin check-in la reserva se libera como no_show
+	CheckInBefore     int                `gorm:"not null;default:15" json:"check_in_before"`     // Minutos antes del inicio en que se abre el check-in
+	CheckInGrace      int                `gorm:"not null;default:15" json:"check_in_grace"`      // Minutos tras el inicio para registrar la llegada
+	ApprovalMode      ApprovalMode       `gorm:"type:varchar(20);default:'admin'" json:"approval_mode"`
 	CreatedAt         time.Time          `json:"created_at"`
 	UpdatedAt         time.Time          `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt     `gorm:"index" json:"-"`
 	AvailabilitySlots []AvailabilitySlot `gorm:"foreignKey:ResourceID;constraint:OnDelete:CASCADE" json:"availability_slots,omitempty"`
 	Bookings          []Booking          `gorm:"foreignKey:ResourceID" json:"bookings,omitempty"`
+	Approvers         []User             `gorm:"many2many:resource_approvers" json:"approvers,omitempty"`
 }
 
 func (Resource) TableName() string {
 	return "resources"
 }
+
+// TurnoverGap devuelve la separación mínima entre dos reservas del recurso:
+// la limpieza de la anterior más la preparación de la siguiente
+func (r *Resource) TurnoverGap() time.Duration {
+	return time.Duration(r.BufferBefore+r.BufferAfter) * time.Minute
+}
+
+// PendingExpiry devuelve cuándo caduca una reserva pendiente creada en now, o nil si no caduca.
+// Con confirmación automática las reservas nunca quedan pendientes.
+func (r *Resource) PendingExpiry(now time.Time) *time.Time {
+	if r.HoldTTL <= 0 || r.ApprovalMode == ApprovalAuto {
+		return nil
+	}
+	expiresAt := now.Add(time.Duration(r.HoldTTL) * time.Minute).UTC()
+	return &expiresAt
+}
+
+// InitialStatus devuelve el estado con el que se crea una reserva según el modo de aprobación
+func (r *Resource) InitialStatus() BookingStatus {
+	if r.ApprovalMode == ApprovalAuto {
+		return StatusConfirmed
+	}
+	return StatusPending
+}
+
+// CheckInOpensAt devuelve desde cuándo se puede registrar la llegada a una reserva que empieza en start
+func (r *Resource) CheckInOpensAt(start time.Time) time.Time {
+	return start.Add(-time.Duration(r.CheckInBefore) * time.Minute)
+}
+
+// CheckInClosesAt devuelve hasta cuándo se puede registrar la llegada: el fin del periodo de gracia,
+// sin pasar del final de la reserva
+func (r *Resource) CheckInClosesAt(start, end time.Time) time.Time {
+	closesAt := start.Add(time.Duration(r.CheckInGrace) * time.Minute)
+	if closesAt.After(end) {
+		return end
+	}
+	return closesAt
+}
+
+// Location devuelve la zona horaria del recurso (UTC si no está configurada o es inválida)
+func (r *Resource) Location() *time.Location {
+	if r.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
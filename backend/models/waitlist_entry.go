@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"   // En espera de que se libere el horario
+	WaitlistPromoted  WaitlistStatus = "promoted"  // Se creó la reserva automáticamente
+	WaitlistCancelled WaitlistStatus = "cancelled" // El usuario salió de la lista
+	WaitlistExpired   WaitlistStatus = "expired"   // El horario pasó sin liberarse
+)
+
+// WaitlistEntry representa a un usuario esperando un recurso en un horario ocupado
+type WaitlistEntry struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	UserID        uint           `gorm:"not null;index" json:"user_id"`
+	ResourceID    uint           `gorm:"not null;index:idx_waitlist_resource_status" json:"resource_id"`
+	StartDatetime time.Time      `gorm:"not null" json:"start_datetime"`
+	EndDatetime   time.Time      `gorm:"not null" json:"end_datetime"`
+	Seats         int            `gorm:"not null;default:1" json:"seats"`
+	Notes         string         `gorm:"type:text" json:"notes"`
+	Position      int            `gorm:"not null" json:"position"` // Orden dentro de la lista del recurso
+	Status        WaitlistStatus `gorm:"type:varchar(20);default:'waiting';index:idx_waitlist_resource_status" json:"status"`
+	BookingID     *uint          `json:"booking_id"` // Reserva creada al promocionar
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relaciones
+	User     User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Resource Resource `gorm:"foreignKey:ResourceID" json:"resource,omitempty"`
+}
+
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
@@ -15,6 +15,13 @@ func NewAvailabilityRepository(db *gorm.DB) *AvailabilityRepository {
 	return &AvailabilityRepository{db: db}
 }
 
+// WithTransaction ejecuta fn dentro de una transacción con un repositorio ligado a ella
+func (r *AvailabilityRepository) WithTransaction(fn func(txRepo *AvailabilityRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&AvailabilityRepository{db: tx})
+	})
+}
+
 // FindByResourceID obtiene todos los horarios de un recurso
 func (r *AvailabilityRepository) FindByResourceID(resourceID uint) ([]models.AvailabilitySlot, error) {
 	var slots []models.AvailabilitySlot
@@ -22,6 +29,16 @@ func (r *AvailabilityRepository) FindByResourceID(resourceID uint) ([]models.Ava
 	return slots, err
 }
 
+// FindByResourceIDs obtiene los horarios de varios recursos en una sola consulta
+func (r *AvailabilityRepository) FindByResourceIDs(resourceIDs []uint) ([]models.AvailabilitySlot, error) {
+	var slots []models.AvailabilitySlot
+	if len(resourceIDs) == 0 {
+		return slots, nil
+	}
+	err := r.db.Where("resource_id IN ?", resourceIDs).Order("resource_id, day_of_week, start_time").Find(&slots).Error
+	return slots, err
+}
+
 // FindByID busca un horario por ID
 func (r *AvailabilityRepository) FindByID(id uint) (*models.AvailabilitySlot, error) {
 	var slot models.AvailabilitySlot
@@ -83,3 +100,78 @@ func (r *AvailabilityRepository) CheckOverlap(resourceID uint, dayOfWeek, startT
 
 	return count > 0, nil
 }
+
+// FindOverrides obtiene las excepciones de horario de un recurso entre dos fechas (AAAA-MM-DD, inclusive)
+func (r *AvailabilityRepository) FindOverrides(resourceID uint, fromDate, toDate string) ([]models.AvailabilityOverride, error) {
+	var overrides []models.AvailabilityOverride
+	err := r.db.Where("resource_id = ? AND date BETWEEN ? AND ?", resourceID, fromDate, toDate).
+		Order("date, start_time").
+		Find(&overrides).Error
+	return overrides, err
+}
+
+// FindOverridesByResourceIDs obtiene las excepciones de varios recursos en una sola consulta
+func (r *AvailabilityRepository) FindOverridesByResourceIDs(resourceIDs []uint, fromDate, toDate string) ([]models.AvailabilityOverride, error) {
+	var overrides []models.AvailabilityOverride
+	if len(resourceIDs) == 0 {
+		return overrides, nil
+	}
+	err := r.db.Where("resource_id IN ? AND date BETWEEN ? AND ?", resourceIDs, fromDate, toDate).
+		Order("resource_id, date, start_time").
+		Find(&overrides).Error
+	return overrides, err
+}
+
+// FindOverrideByID busca una excepción de horario por ID
+func (r *AvailabilityRepository) FindOverrideByID(id uint) (*models.AvailabilityOverride, error) {
+	var override models.AvailabilityOverride
+	err := r.db.First(&override, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("excepción de horario no encontrada")
+		}
+		return nil, err
+	}
+	return &override, nil
+}
+
+// CreateOverride crea una excepción de horario
+func (r *AvailabilityRepository) CreateOverride(override *models.AvailabilityOverride) error {
+	return r.db.Create(override).Error
+}
+
+// UpdateOverride actualiza una excepción de horario
+func (r *AvailabilityRepository) UpdateOverride(override *models.AvailabilityOverride) error {
+	return r.db.Save(override).Error
+}
+
+// DeleteOverride elimina una excepción de horario
+func (r *AvailabilityRepository) DeleteOverride(id uint) error {
+	result := r.db.Delete(&models.AvailabilityOverride{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("excepción de horario no encontrada")
+	}
+	return nil
+}
+
+// CheckOverrideOverlap verifica si la franja se solapa con otra excepción del mismo día
+func (r *AvailabilityRepository) CheckOverrideOverlap(resourceID uint, date, startTime, endTime string, excludeID *uint) (bool, error) {
+	query := r.db.Model(&models.AvailabilityOverride{}).
+		Where("resource_id = ? AND date = ?", resourceID, date).
+		Where("start_time < ? AND end_time > ?", endTime, startTime)
+
+	// Excluir el ID actual si estamos actualizando
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"Reservify/models"
+	"Reservify/utils"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BlackoutRepository struct {
+	db *gorm.DB
+}
+
+func NewBlackoutRepository(db *gorm.DB) *BlackoutRepository {
+	return &BlackoutRepository{db: db}
+}
+
+// FindAll obtiene todos los cierres con paginación
+func (r *BlackoutRepository) FindAll(params utils.PaginationParams) ([]models.Blackout, int64, error) {
+	var blackouts []models.Blackout
+	var total int64
+
+	query := r.db.Model(&models.Blackout{})
+
+	// Búsqueda por motivo
+	if params.Search != "" {
+		query = query.Where("reason LIKE ?", "%"+params.Search+"%")
+	}
+
+	// Contar total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Obtener datos con paginación
+	offset := params.CalculateOffset()
+	if err := query.Offset(offset).Limit(params.PageSize).Order("created_at DESC").Find(&blackouts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return blackouts, total, nil
+}
+
+// FindByID busca un cierre por ID
+func (r *BlackoutRepository) FindByID(id uint) (*models.Blackout, error) {
+	var blackout models.Blackout
+	err := r.db.First(&blackout, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("cierre no encontrado")
+		}
+		return nil, err
+	}
+	return &blackout, nil
+}
+
+// Create crea un nuevo cierre
+func (r *BlackoutRepository) Create(blackout *models.Blackout) error {
+	return r.db.Create(blackout).Error
+}
+
+// Update actualiza un cierre
+func (r *BlackoutRepository) Update(blackout *models.Blackout) error {
+	return r.db.Save(blackout).Error
+}
+
+// Delete elimina un cierre
+func (r *BlackoutRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Blackout{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("cierre no encontrado")
+	}
+	return nil
+}
+
+// FindInRange obtiene los cierres que pueden afectar a los recursos indicados entre from y to.
+// Los cierres de día completo se buscan con un día de margen porque se evalúan en la zona de cada recurso.
+func (r *BlackoutRepository) FindInRange(resources []models.Resource, from, to time.Time) ([]models.Blackout, error) {
+	var blackouts []models.Blackout
+	if len(resources) == 0 {
+		return blackouts, nil
+	}
+
+	ids := make([]uint, 0, len(resources))
+	categories := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		ids = append(ids, resource.ID)
+		if resource.Category != "" {
+			categories = append(categories, resource.Category)
+		}
+	}
+
+	scope := r.db.Where("resource_id IN ?", ids).
+		Or("resource_id IS NULL AND category = ''")
+	if len(categories) > 0 {
+		scope = scope.Or("resource_id IS NULL AND category IN ?", categories)
+	}
+
+	fromDate := from.UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	toDate := to.UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	period := r.db.Where("all_day = ? AND start_datetime < ? AND end_datetime > ?", false, to, from).
+		Or("all_day = ? AND start_date <= ? AND end_date >= ?", true, toDate, fromDate)
+
+	err := r.db.Where(scope).Where(period).Order("id ASC").Find(&blackouts).Error
+	return blackouts, err
+}
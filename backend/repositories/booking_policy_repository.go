@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"Reservify/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type BookingPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewBookingPolicyRepository(db *gorm.DB) *BookingPolicyRepository {
+	return &BookingPolicyRepository{db: db}
+}
+
+// FindAll obtiene todas las políticas (globales primero, luego categorías y recursos)
+func (r *BookingPolicyRepository) FindAll() ([]models.BookingPolicy, error) {
+	var policies []models.BookingPolicy
+	err := r.db.Order("resource_id IS NOT NULL, category, resource_id").Find(&policies).Error
+	return policies, err
+}
+
+// FindByID busca una política por ID
+func (r *BookingPolicyRepository) FindByID(id uint) (*models.BookingPolicy, error) {
+	var policy models.BookingPolicy
+	err := r.db.First(&policy, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("política no encontrada")
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// FindByScope busca la política de un recurso, de una categoría o la global
+func (r *BookingPolicyRepository) FindByScope(resourceID *uint, category string) (*models.BookingPolicy, error) {
+	var policy models.BookingPolicy
+	query := r.db
+	if resourceID != nil {
+		query = query.Where("resource_id = ?", *resourceID)
+	} else {
+		query = query.Where("resource_id IS NULL AND category = ?", category)
+	}
+
+	err := query.First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("política no encontrada")
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// FindApplicable obtiene las políticas que aplican a un recurso: la suya, la de su categoría y la global
+func (r *BookingPolicyRepository) FindApplicable(resource *models.Resource) ([]models.BookingPolicy, error) {
+	var policies []models.BookingPolicy
+	query := r.db.Where("resource_id = ?", resource.ID).
+		Or("resource_id IS NULL AND category = ''")
+	if resource.Category != "" {
+		query = query.Or("resource_id IS NULL AND category = ?", resource.Category)
+	}
+	err := query.Find(&policies).Error
+	return policies, err
+}
+
+// Save crea o actualiza una política
+func (r *BookingPolicyRepository) Save(policy *models.BookingPolicy) error {
+	return r.db.Save(policy).Error
+}
+
+// Delete elimina una política
+func (r *BookingPolicyRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.BookingPolicy{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("política no encontrada")
+	}
+	return nil
+}
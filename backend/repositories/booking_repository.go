@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookingRepository struct {
@@ -17,6 +18,28 @@ func NewBookingRepository(db *gorm.DB) *BookingRepository {
 	return &BookingRepository{db: db}
 }
 
+// WithTransaction ejecuta fn dentro de una transacción con un repositorio ligado a ella
+func (r *BookingRepository) WithTransaction(fn func(txRepo *BookingRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&BookingRepository{db: tx})
+	})
+}
+
+// LockResource bloquea la fila del recurso hasta que termine la transacción actual
+func (r *BookingRepository) LockResource(resourceID uint) error {
+	var resource models.Resource
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&resource, resourceID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("recurso no encontrado")
+		}
+		return err
+	}
+	return nil
+}
+
 // FindAll obtiene todas las reservas con paginación
 func (r *BookingRepository) FindAll(params utils.PaginationParams) ([]models.Booking, int64, error) {
 	var bookings []models.Booking
@@ -40,13 +63,15 @@ func (r *BookingRepository) FindAll(params utils.PaginationParams) ([]models.Boo
 	return bookings, total, nil
 }
 
-// FindByUserID obtiene las reservas de un usuario
+// FindByUserID obtiene las reservas de un usuario, tanto las suyas como aquellas a las que está invitado
 func (r *BookingRepository) FindByUserID(userID uint, params utils.PaginationParams) ([]models.Booking, int64, error) {
 	var bookings []models.Booking
 	var total int64
 
 	query := r.db.Model(&models.Booking{}).
-		Where("user_id = ?", userID).
+		Where("user_id = ? OR id IN (?)", userID,
+			r.db.Model(&models.BookingAttendee{}).Select("booking_id").Where("user_id = ?", userID)).
+		Preload("User").
 		Preload("Resource")
 
 	// Contar total
@@ -86,6 +111,21 @@ func (r *BookingRepository) Update(booking *models.Booking) error {
 	return r.db.Save(booking).Error
 }
 
+// CreateSeries crea la cabecera de una serie de reservas recurrentes
+func (r *BookingRepository) CreateSeries(series *models.BookingSeries) error {
+	return r.db.Create(series).Error
+}
+
+// FindBySeriesID obtiene las reservas de una serie que empiezan a partir de from
+func (r *BookingRepository) FindBySeriesID(seriesID uint, from time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.db.Where("series_id = ? AND start_datetime >= ?", seriesID, from).
+		Preload("Resource").
+		Order("start_datetime ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
 // Delete elimina una reserva (soft delete)
 func (r *BookingRepository) Delete(id uint) error {
 	result := r.db.Delete(&models.Booking{}, id)
@@ -100,6 +140,15 @@ func (r *BookingRepository) Delete(id uint) error {
 
 // CheckOverlap verifica si hay solapamiento de reservas para un recurso
 func (r *BookingRepository) CheckOverlap(resourceID uint, startDatetime, endDatetime time.Time, excludeID *uint) (bool, error) {
+	var excludeIDs []uint
+	if excludeID != nil {
+		excludeIDs = append(excludeIDs, *excludeID)
+	}
+	return r.CheckOverlapExcluding(resourceID, startDatetime, endDatetime, excludeIDs)
+}
+
+// CheckOverlapExcluding verifica solapamientos ignorando varias reservas (p. ej. las de una serie que se está moviendo)
+func (r *BookingRepository) CheckOverlapExcluding(resourceID uint, startDatetime, endDatetime time.Time, excludeIDs []uint) (bool, error) {
 	query := r.db.Model(&models.Booking{}).
 		Where("resource_id = ?", resourceID).
 		Where("status IN ?", []string{"pending", "confirmed"}). // Solo considerar reservas activas
@@ -107,9 +156,9 @@ func (r *BookingRepository) CheckOverlap(resourceID uint, startDatetime, endDate
 			r.db.Where("start_datetime < ? AND end_datetime > ?", endDatetime, startDatetime), // Solapamiento
 		)
 
-	// Excluir el ID actual si estamos actualizando
-	if excludeID != nil {
-		query = query.Where("id != ?", *excludeID)
+	// Excluir las reservas indicadas si estamos actualizando
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
 	}
 
 	var count int64
@@ -120,6 +169,34 @@ func (r *BookingRepository) CheckOverlap(resourceID uint, startDatetime, endDate
 	return count > 0, nil
 }
 
+// FindOverlapping obtiene las reservas activas que se solapan con el intervalo
+func (r *BookingRepository) FindOverlapping(resourceID uint, startDatetime, endDatetime time.Time, excludeIDs []uint) ([]models.Booking, error) {
+	var bookings []models.Booking
+	query := r.db.Where("resource_id = ?", resourceID).
+		Where("status IN ?", []string{"pending", "confirmed"}).
+		Where("start_datetime < ? AND end_datetime > ?", endDatetime, startDatetime)
+
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+
+	err := query.Order("start_datetime ASC").Find(&bookings).Error
+	return bookings, err
+}
+
+// FindOverlappingForResources obtiene las reservas activas de varios recursos que se solapan con el intervalo
+func (r *BookingRepository) FindOverlappingForResources(resourceIDs []uint, startDatetime, endDatetime time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	if len(resourceIDs) == 0 {
+		return bookings, nil
+	}
+	err := r.db.Where("resource_id IN ?", resourceIDs).
+		Where("status IN ?", []string{"pending", "confirmed"}).
+		Where("start_datetime < ? AND end_datetime > ?", endDatetime, startDatetime).
+		Find(&bookings).Error
+	return bookings, err
+}
+
 // CountByStatus cuenta reservas por estado
 func (r *BookingRepository) CountByStatus(status string) (int64, error) {
 	var count int64
@@ -159,6 +236,26 @@ func (r *BookingRepository) GetUpcomingBookings(userID uint, limit int) ([]model
 	return bookings, err
 }
 
+// FindUpcomingByResource obtiene las reservas activas de un recurso que terminan después de from
+func (r *BookingRepository) FindUpcomingByResource(resourceID uint, from time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.db.Where("resource_id = ? AND end_datetime > ?", resourceID, from).
+		Where("status IN ?", []string{"pending", "confirmed"}).
+		Order("start_datetime ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// FindForQuota obtiene las reservas de un usuario que cuentan para sus cuotas y terminan después de from
+func (r *BookingRepository) FindForQuota(userID uint, from time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.db.Where("user_id = ? AND end_datetime > ?", userID, from).
+		Where("status IN ?", []string{"pending", "confirmed", "completed"}).
+		Preload("Resource").
+		Find(&bookings).Error
+	return bookings, err
+}
+
 // GetBookingsByDateRange obtiene reservas en un rango de fechas
 func (r *BookingRepository) GetBookingsByDateRange(resourceID uint, startDate, endDate time.Time) ([]models.Booking, error) {
 	var bookings []models.Booking
@@ -169,3 +266,343 @@ func (r *BookingRepository) GetBookingsByDateRange(resourceID uint, startDate, e
 		Find(&bookings).Error
 	return bookings, err
 }
+
+// FindExpiredPending obtiene las reservas pendientes cuyo plazo de confirmación venció antes de now
+func (r *BookingRepository) FindExpiredPending(now time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.db.Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", models.StatusPending, now).
+		Preload("Resource").
+		Order("expires_at ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// ExpirePending cancela una reserva solo si sigue pendiente y vencida.
+// Devuelve false si otra operación (p. ej. una confirmación) se adelantó.
+func (r *BookingRepository) ExpirePending(id uint, reason string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND status = ? AND expires_at IS NOT NULL AND expires_at <= ?", id, models.StatusPending, now).
+		Updates(map[string]interface{}{
+			"status":        models.StatusCancelled,
+			"cancel_reason": reason,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CompleteHold convierte una retención vigente en una reserva normal con el estado y el vencimiento indicados
+func (r *BookingRepository) CompleteHold(id uint, status models.BookingStatus, expiresAt *time.Time, now time.Time) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND status = ? AND is_hold = ? AND expires_at > ?", id, models.StatusPending, true, now).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"is_hold":    false,
+			"expires_at": expiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindAwaitingApproval obtiene las reservas pendientes de aprobación (sin retenciones) que aún no
+// empezaron, de los recursos indicados o de todos si resourceIDs es nil
+func (r *BookingRepository) FindAwaitingApproval(resourceIDs []uint, now time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	query := r.db.Where("status = ? AND is_hold = ? AND start_datetime > ?", models.StatusPending, false, now)
+	if resourceIDs != nil {
+		query = query.Where("resource_id IN ?", resourceIDs)
+	}
+	err := query.Preload("User").
+		Preload("Resource").
+		Order("start_datetime ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// Decide aprueba o rechaza una reserva solo si sigue pendiente de aprobación, registrando quién
+// decidió y por qué. Devuelve false si otra decisión o una expiración se adelantó.
+func (r *BookingRepository) Decide(id uint, status models.BookingStatus, deciderID uint, note string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND status = ? AND is_hold = ?", id, models.StatusPending, false).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"decided_by":    deciderID,
+			"decided_at":    now,
+			"decision_note": note,
+			"expires_at":    nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindFinished obtiene las reservas confirmadas que ya terminaron
+func (r *BookingRepository) FindFinished(now time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.db.Where("status = ? AND end_datetime <= ?", models.StatusConfirmed, now).
+		Preload("Resource").
+		Order("end_datetime ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// FindUnclaimed obtiene las reservas confirmadas en curso sin llegada registrada de recursos que exigen check-in
+func (r *BookingRepository) FindUnclaimed(now time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	requiresCheckIn := r.db.Model(&models.Resource{}).Select("id").Where("requires_check_in = ?", true)
+	err := r.db.Where("status = ? AND checked_in_at IS NULL", models.StatusConfirmed).
+		Where("start_datetime <= ? AND end_datetime > ?", now, now).
+		Where("resource_id IN (?)", requiresCheckIn).
+		Preload("Resource").
+		Order("start_datetime ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// ReleaseUnclaimed pasa una reserva a no_show solo si sigue confirmada y sin llegada registrada
+func (r *BookingRepository) ReleaseUnclaimed(id uint) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND status = ? AND checked_in_at IS NULL", id, models.StatusConfirmed).
+		Update("status", models.StatusNoShow)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ChangeEnd mueve el fin de una reserva confirmada y actualiza su precio solo si el fin sigue
+// siendo currentEnd. Devuelve false si otra operación la cambió antes.
+func (r *BookingRepository) ChangeEnd(id uint, currentEnd, newEnd time.Time, totalPrice float64) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND status = ? AND end_datetime = ?", id, models.StatusConfirmed, currentEnd).
+		Updates(map[string]interface{}{"end_datetime": newEnd, "total_price": totalPrice})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// TransitionStatus cambia el estado de una reserva solo si sigue en from.
+// Devuelve false si otra operación la cambió antes.
+func (r *BookingRepository) TransitionStatus(id uint, from, to models.BookingStatus) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetCancellationFeeTotal suma los cargos cobrados por cancelaciones
+func (r *BookingRepository) GetCancellationFeeTotal() (float64, error) {
+	var total float64
+	err := r.db.Model(&models.Booking{}).
+		Where("status = ?", models.StatusCancelled).
+		Select("COALESCE(SUM(cancel_fee), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// CreateEvent guarda un evento en el historial de una reserva
+func (r *BookingRepository) CreateEvent(event *models.BookingEvent) error {
+	return r.db.Create(event).Error
+}
+
+// FindEvents obtiene el historial de una reserva en orden cronológico
+func (r *BookingRepository) FindEvents(bookingID uint) ([]models.BookingEvent, error) {
+	var events []models.BookingEvent
+	err := r.db.Where("booking_id = ?", bookingID).
+		Preload("Actor").
+		Order("created_at ASC, id ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// CreateReservation crea la cabecera de una reserva múltiple
+func (r *BookingRepository) CreateReservation(reservation *models.Reservation) error {
+	return r.db.Create(reservation).Error
+}
+
+// UpdateReservation actualiza los datos generales de una reserva múltiple
+func (r *BookingRepository) UpdateReservation(reservation *models.Reservation) error {
+	return r.db.Omit("Bookings", "User").Save(reservation).Error
+}
+
+// FindReservationByID busca una reserva múltiple con sus reservas
+func (r *BookingRepository) FindReservationByID(id uint) (*models.Reservation, error) {
+	var reservation models.Reservation
+	err := r.db.Preload("Bookings", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_datetime ASC, id ASC")
+	}).
+		Preload("Bookings.Resource").
+		Preload("Bookings.User").
+		First(&reservation, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reserva múltiple no encontrada")
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// FindReservationsByUserID obtiene las reservas múltiples de un usuario, las más recientes primero
+func (r *BookingRepository) FindReservationsByUserID(userID uint) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	err := r.db.Where("user_id = ?", userID).
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_datetime ASC, id ASC")
+		}).
+		Preload("Bookings.Resource").
+		Order("created_at DESC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// FindAttendees obtiene los invitados de una reserva en el orden en que se añadieron
+func (r *BookingRepository) FindAttendees(bookingID uint) ([]models.BookingAttendee, error) {
+	var attendees []models.BookingAttendee
+	err := r.db.Preload("User").
+		Where("booking_id = ?", bookingID).
+		Order("id ASC").
+		Find(&attendees).Error
+	return attendees, err
+}
+
+// FindAttendee busca la invitación de un usuario registrado a una reserva
+func (r *BookingRepository) FindAttendee(bookingID, userID uint) (*models.BookingAttendee, error) {
+	var attendee models.BookingAttendee
+	err := r.db.Where("booking_id = ? AND user_id = ?", bookingID, userID).First(&attendee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no estás invitado a esta reserva")
+		}
+		return nil, err
+	}
+	return &attendee, nil
+}
+
+// FindAttendances obtiene las invitaciones de un usuario a las reservas indicadas
+func (r *BookingRepository) FindAttendances(userID uint, bookingIDs []uint) ([]models.BookingAttendee, error) {
+	var attendees []models.BookingAttendee
+	if len(bookingIDs) == 0 {
+		return attendees, nil
+	}
+	err := r.db.Where("user_id = ? AND booking_id IN ?", userID, bookingIDs).Find(&attendees).Error
+	return attendees, err
+}
+
+// IsAttendee indica si el usuario está invitado a la reserva
+func (r *BookingRepository) IsAttendee(bookingID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.BookingAttendee{}).
+		Where("booking_id = ? AND user_id = ?", bookingID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ReplaceAttendees deja como invitados de la reserva exactamente los indicados: borra los que ya no
+// están, actualiza los existentes (con ID) y crea los nuevos. Debe llamarse dentro de una transacción.
+func (r *BookingRepository) ReplaceAttendees(bookingID uint, attendees []models.BookingAttendee) error {
+	keepIDs := []uint{0}
+	for _, attendee := range attendees {
+		if attendee.ID != 0 {
+			keepIDs = append(keepIDs, attendee.ID)
+		}
+	}
+
+	err := r.db.Where("booking_id = ? AND id NOT IN ?", bookingID, keepIDs).
+		Delete(&models.BookingAttendee{}).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range attendees {
+		attendees[i].BookingID = bookingID
+		if err := r.db.Omit("User").Save(&attendees[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateAttendee guarda la respuesta de un invitado
+func (r *BookingRepository) UpdateAttendee(attendee *models.BookingAttendee) error {
+	return r.db.Omit("User").Save(attendee).Error
+}
+
+// CreateTransfer registra una propuesta de transferencia
+func (r *BookingRepository) CreateTransfer(transfer *models.BookingTransfer) error {
+	return r.db.Create(transfer).Error
+}
+
+// FindTransferByID busca una transferencia con la reserva y los usuarios involucrados
+func (r *BookingRepository) FindTransferByID(id uint) (*models.BookingTransfer, error) {
+	var transfer models.BookingTransfer
+	err := r.db.Preload("Booking").Preload("Booking.Resource").
+		Preload("FromUser").Preload("ToUser").
+		First(&transfer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("transferencia no encontrada")
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// HasPendingTransfer indica si la reserva tiene una transferencia esperando respuesta
+func (r *BookingRepository) HasPendingTransfer(bookingID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.BookingTransfer{}).
+		Where("booking_id = ? AND status = ?", bookingID, models.TransferPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindTransfersByUser obtiene las transferencias enviadas y recibidas por un usuario, las más recientes primero
+func (r *BookingRepository) FindTransfersByUser(userID uint) ([]models.BookingTransfer, error) {
+	var transfers []models.BookingTransfer
+	err := r.db.Preload("Booking").Preload("Booking.Resource").
+		Preload("FromUser").Preload("ToUser").
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&transfers).Error
+	return transfers, err
+}
+
+// ResolveTransfer cierra una transferencia solo si sigue pendiente.
+// Devuelve false si otra respuesta se adelantó.
+func (r *BookingRepository) ResolveTransfer(id uint, status models.TransferStatus, now time.Time) (bool, error) {
+	result := r.db.Model(&models.BookingTransfer{}).
+		Where("id = ? AND status = ?", id, models.TransferPending).
+		Updates(map[string]interface{}{"status": status, "responded_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// TransferOwnership pasa una reserva activa de fromUserID a toUserID y la separa de su serie,
+// que sigue siendo del titular anterior. Devuelve false si la reserva cambió de titular o de estado.
+func (r *BookingRepository) TransferOwnership(bookingID, fromUserID, toUserID uint) (bool, error) {
+	result := r.db.Model(&models.Booking{}).
+		Where("id = ? AND user_id = ? AND status IN ?", bookingID, fromUserID,
+			[]models.BookingStatus{models.StatusPending, models.StatusConfirmed}).
+		Updates(map[string]interface{}{"user_id": toUserID, "series_id": nil})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteAttendee quita a un usuario registrado de los invitados de una reserva
+func (r *BookingRepository) DeleteAttendee(bookingID, userID uint) error {
+	return r.db.Where("booking_id = ? AND user_id = ?", bookingID, userID).
+		Delete(&models.BookingAttendee{}).Error
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"Reservify/models"
+	"Reservify/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// AcquireLease intenta tomar la tarea name para holder durante ttl.
+// Devuelve false si otra instancia la tiene tomada y su plazo no venció.
+func (r *JobRepository) AcquireLease(name, holder string, ttl time.Duration, now time.Time) (bool, error) {
+	// Crear la fila la primera vez; si ya existe no se toca
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.JobLease{Name: name, LockedUntil: now}).Error
+	if err != nil {
+		return false, err
+	}
+
+	result := r.db.Model(&models.JobLease{}).
+		Where("name = ? AND (locked_until <= ? OR holder = ?)", name, now, holder).
+		Updates(map[string]interface{}{
+			"holder":       holder,
+			"locked_until": now.Add(ttl),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CreateRun registra el inicio de una ejecución
+func (r *JobRepository) CreateRun(run *models.JobRun) error {
+	return r.db.Create(run).Error
+}
+
+// UpdateRun guarda el resultado de una ejecución
+func (r *JobRepository) UpdateRun(run *models.JobRun) error {
+	return r.db.Save(run).Error
+}
+
+// FindRuns obtiene las ejecuciones más recientes, opcionalmente de una sola tarea
+func (r *JobRepository) FindRuns(job string, params utils.PaginationParams) ([]models.JobRun, int64, error) {
+	var runs []models.JobRun
+	var total int64
+
+	query := r.db.Model(&models.JobRun{})
+	if job != "" {
+		query = query.Where("job = ?", job)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := params.CalculateOffset()
+	if err := query.Offset(offset).Limit(params.PageSize).Order("started_at DESC, id DESC").Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return runs, total, nil
+}
+
+// FindLatestRuns obtiene la última ejecución de cada tarea
+func (r *JobRepository) FindLatestRuns() ([]models.JobRun, error) {
+	var runs []models.JobRun
+	latest := r.db.Model(&models.JobRun{}).Select("MAX(id)").Group("job")
+	err := r.db.Where("id IN (?)", latest).Order("job ASC").Find(&runs).Error
+	return runs, err
+}
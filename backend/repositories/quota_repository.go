@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"Reservify/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type QuotaRepository struct {
+	db *gorm.DB
+}
+
+func NewQuotaRepository(db *gorm.DB) *QuotaRepository {
+	return &QuotaRepository{db: db}
+}
+
+// FindAll obtiene todas las cuotas configuradas
+func (r *QuotaRepository) FindAll() ([]models.BookingQuota, error) {
+	var quotas []models.BookingQuota
+	err := r.db.Order("user_id IS NOT NULL, role, user_id, category").Find(&quotas).Error
+	return quotas, err
+}
+
+// FindByScope busca la cuota de un usuario, de un rol o la global para una categoría
+func (r *QuotaRepository) FindByScope(userID *uint, role, category string) (*models.BookingQuota, error) {
+	var quota models.BookingQuota
+	query := r.db.Where("category = ?", category)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	} else {
+		query = query.Where("user_id IS NULL AND role = ?", role)
+	}
+
+	err := query.First(&quota).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("cuota no encontrada")
+		}
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// FindApplicable obtiene las cuotas que aplican a un usuario: las suyas, las de su rol y las globales
+func (r *QuotaRepository) FindApplicable(userID uint, role models.UserRole) ([]models.BookingQuota, error) {
+	var quotas []models.BookingQuota
+	err := r.db.Where("user_id = ?", userID).
+		Or("user_id IS NULL AND role = ?", role).
+		Or("user_id IS NULL AND role = ''").
+		Find(&quotas).Error
+	return quotas, err
+}
+
+// Save crea o actualiza una cuota
+func (r *QuotaRepository) Save(quota *models.BookingQuota) error {
+	return r.db.Save(quota).Error
+}
+
+// Delete elimina una cuota
+func (r *QuotaRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.BookingQuota{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("cuota no encontrada")
+	}
+	return nil
+}
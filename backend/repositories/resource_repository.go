@@ -69,6 +69,33 @@ func (r *ResourceRepository) FindByCategory(category string, params utils.Pagina
 	return resources, total, nil
 }
 
+// ResourceSearchFilters representa los filtros de búsqueda de recursos
+type ResourceSearchFilters struct {
+	Category        string
+	MinCapacity     int
+	MaxPricePerHour *float64
+}
+
+// FindCandidates obtiene recursos activos que cumplen los filtros, ordenados por mejor ajuste
+func (r *ResourceRepository) FindCandidates(filters ResourceSearchFilters) ([]models.Resource, error) {
+	var resources []models.Resource
+
+	query := r.db.Where("is_active = ?", true)
+	if filters.Category != "" {
+		query = query.Where("category = ?", filters.Category)
+	}
+	if filters.MinCapacity > 0 {
+		query = query.Where("capacity >= ?", filters.MinCapacity)
+	}
+	if filters.MaxPricePerHour != nil {
+		query = query.Where("price_per_hour <= ?", *filters.MaxPricePerHour)
+	}
+
+	// Menor capacidad suficiente primero, luego el más barato
+	err := query.Order("capacity ASC, price_per_hour ASC, id ASC").Find(&resources).Error
+	return resources, err
+}
+
 // FindByID busca un recurso por ID
 func (r *ResourceRepository) FindByID(id uint) (*models.Resource, error) {
 	var resource models.Resource
@@ -82,6 +109,20 @@ func (r *ResourceRepository) FindByID(id uint) (*models.Resource, error) {
 	return &resource, nil
 }
 
+// FindInScope obtiene los recursos de un recurso concreto, de una categoría o todos si no se indica ninguno
+func (r *ResourceRepository) FindInScope(resourceID *uint, category string) ([]models.Resource, error) {
+	var resources []models.Resource
+	query := r.db.Model(&models.Resource{})
+	switch {
+	case resourceID != nil:
+		query = query.Where("id = ?", *resourceID)
+	case category != "":
+		query = query.Where("category = ?", category)
+	}
+	err := query.Order("id ASC").Find(&resources).Error
+	return resources, err
+}
+
 // Create crea un nuevo recurso
 func (r *ResourceRepository) Create(resource *models.Resource) error {
 	return r.db.Create(resource).Error
@@ -92,6 +133,45 @@ func (r *ResourceRepository) Update(resource *models.Resource) error {
 	return r.db.Save(resource).Error
 }
 
+// UpdateBuffersByCategory actualiza los márgenes de todos los recursos de una categoría
+func (r *ResourceRepository) UpdateBuffersByCategory(category string, before, after int) (int64, error) {
+	result := r.db.Model(&models.Resource{}).
+		Where("category = ?", category).
+		Updates(map[string]interface{}{"buffer_before": before, "buffer_after": after})
+	return result.RowsAffected, result.Error
+}
+
+// FindApprovers obtiene los aprobadores designados de un recurso
+func (r *ResourceRepository) FindApprovers(resource *models.Resource) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Model(resource).Order("users.id ASC").Association("Approvers").Find(&users)
+	return users, err
+}
+
+// ReplaceApprovers reemplaza los aprobadores designados de un recurso
+func (r *ResourceRepository) ReplaceApprovers(resource *models.Resource, users []models.User) error {
+	return r.db.Model(resource).Association("Approvers").Replace(users)
+}
+
+// IsApprover indica si el usuario es aprobador designado del recurso
+func (r *ResourceRepository) IsApprover(resourceID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Table("resource_approvers").
+		Where("resource_id = ? AND user_id = ?", resourceID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindApprovableIDs obtiene los recursos cuyas reservas puede aprobar el usuario como aprobador designado
+func (r *ResourceRepository) FindApprovableIDs(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Resource{}).
+		Joins("JOIN resource_approvers ON resource_approvers.resource_id = resources.id").
+		Where("resource_approvers.user_id = ? AND resources.approval_mode = ?", userID, models.ApprovalApprovers).
+		Pluck("resources.id", &ids).Error
+	return ids, err
+}
+
 // Delete elimina un recurso (soft delete)
 func (r *ResourceRepository) Delete(id uint) error {
 	result := r.db.Delete(&models.Resource{}, id)
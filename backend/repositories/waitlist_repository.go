@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"Reservify/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) *WaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+// WithTransaction ejecuta fn dentro de una transacción con un repositorio ligado a ella
+func (r *WaitlistRepository) WithTransaction(fn func(txRepo *WaitlistRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&WaitlistRepository{db: tx})
+	})
+}
+
+// FindByID busca una entrada de la lista de espera por ID
+func (r *WaitlistRepository) FindByID(id uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.db.Preload("Resource").First(&entry, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("entrada de lista de espera no encontrada")
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindByUserID obtiene las entradas de un usuario, las más recientes primero
+func (r *WaitlistRepository) FindByUserID(userID uint) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.db.Where("user_id = ?", userID).
+		Preload("Resource").
+		Order("created_at DESC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// FindWaitingByResource obtiene las entradas en espera de un recurso en orden de prioridad
+func (r *WaitlistRepository) FindWaitingByResource(resourceID uint) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.db.Where("resource_id = ? AND status = ?", resourceID, models.WaitlistWaiting).
+		Preload("User").
+		Order("position ASC, id ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// FindWaitingOverlapping obtiene las entradas en espera que se solapan con un intervalo, en orden de prioridad
+func (r *WaitlistRepository) FindWaitingOverlapping(resourceID uint, start, end time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.db.Where("resource_id = ? AND status = ?", resourceID, models.WaitlistWaiting).
+		Where("start_datetime < ? AND end_datetime > ?", end, start).
+		Order("position ASC, id ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// NextPosition devuelve la posición para una nueva entrada al final de la lista del recurso
+func (r *WaitlistRepository) NextPosition(resourceID uint) (int, error) {
+	var max *int
+	err := r.db.Model(&models.WaitlistEntry{}).
+		Where("resource_id = ? AND status = ?", resourceID, models.WaitlistWaiting).
+		Select("MAX(position)").
+		Scan(&max).Error
+	if err != nil || max == nil {
+		return 1, err
+	}
+	return *max + 1, nil
+}
+
+// Create crea una nueva entrada
+func (r *WaitlistRepository) Create(entry *models.WaitlistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// Update actualiza una entrada
+func (r *WaitlistRepository) Update(entry *models.WaitlistEntry) error {
+	return r.db.Save(entry).Error
+}
+
+// UpdatePosition cambia la posición de una entrada
+func (r *WaitlistRepository) UpdatePosition(id uint, position int) error {
+	return r.db.Model(&models.WaitlistEntry{}).Where("id = ?", id).Update("position", position).Error
+}
+
+// TransitionStatus cambia el estado de una entrada solo si sigue en el estado esperado.
+// Devuelve false si otra operación ya la modificó.
+func (r *WaitlistRepository) TransitionStatus(id uint, from, to models.WaitlistStatus) (bool, error) {
+	result := r.db.Model(&models.WaitlistEntry{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SetBooking asocia la reserva creada al promocionar una entrada
+func (r *WaitlistRepository) SetBooking(id uint, bookingID uint) error {
+	return r.db.Model(&models.WaitlistEntry{}).Where("id = ?", id).Update("booking_id", bookingID).Error
+}
@@ -20,13 +20,24 @@ func SetupRoutes(router *gin.Engine) {
 	resourceRepo := repositories.NewResourceRepository(config.DB)
 	availabilityRepo := repositories.NewAvailabilityRepository(config.DB)
 	bookingRepo := repositories.NewBookingRepository(config.DB)
+	blackoutRepo := repositories.NewBlackoutRepository(config.DB)
+	notificationRepo := repositories.NewNotificationRepository(config.DB)
+	policyRepo := repositories.NewBookingPolicyRepository(config.DB)
+	quotaRepo := repositories.NewQuotaRepository(config.DB)
+	waitlistRepo := repositories.NewWaitlistRepository(config.DB)
+	jobRepo := repositories.NewJobRepository(config.DB)
 
 	// Inicializar servicios
 	authService := services.NewAuthService(authRepo)
 	userService := services.NewUserService(userRepo, authRepo)
-	resourceService := services.NewResourceService(resourceRepo)
-	availabilityService := services.NewAvailabilityService(availabilityRepo, resourceRepo)
-	bookingService := services.NewBookingService(bookingRepo, resourceRepo, userRepo)
+	resourceService := services.NewResourceService(resourceRepo, userRepo)
+	availabilityService := services.NewAvailabilityService(availabilityRepo, resourceRepo, bookingRepo, blackoutRepo)
+	bookingService := services.NewBookingService(bookingRepo, resourceRepo, userRepo, availabilityRepo, blackoutRepo, policyRepo, quotaRepo, waitlistRepo, notificationRepo)
+	blackoutService := services.NewBlackoutService(blackoutRepo, resourceRepo, bookingRepo, notificationRepo)
+	policyService := services.NewBookingPolicyService(policyRepo, resourceRepo)
+	quotaService := services.NewQuotaService(quotaRepo, userRepo, bookingRepo)
+	waitlistService := services.NewWaitlistService(waitlistRepo, resourceRepo, bookingRepo)
+	jobService := services.NewJobService(jobRepo)
 
 	// Inicializar controladores
 	authController := controllers.NewAuthController(authService)
@@ -34,6 +45,14 @@ func SetupRoutes(router *gin.Engine) {
 	resourceController := controllers.NewResourceController(resourceService)
 	availabilityController := controllers.NewAvailabilityController(availabilityService)
 	bookingController := controllers.NewBookingController(bookingService)
+	blackoutController := controllers.NewBlackoutController(blackoutService)
+	policyController := controllers.NewBookingPolicyController(policyService)
+	quotaController := controllers.NewQuotaController(quotaService)
+	waitlistController := controllers.NewWaitlistController(waitlistService)
+	reservationController := controllers.NewReservationController(bookingService)
+	approvalController := controllers.NewApprovalController(bookingService)
+	transferController := controllers.NewTransferController(bookingService)
+	jobController := controllers.NewJobController(jobService)
 
 	// Grupo de API
 	api := router.Group("/api")
@@ -52,9 +71,13 @@ func SetupRoutes(router *gin.Engine) {
 		{
 			resources.GET("", resourceController.GetAllResources)
 			resources.GET("/categories", resourceController.GetCategories)
+			resources.GET("/search", availabilityController.SearchAvailableResources)
 			resources.GET("/category/:category", resourceController.GetResourcesByCategory)
 			resources.GET("/:id", resourceController.GetResourceByID)
 			resources.GET("/:id/availability", availabilityController.GetAvailabilityByResource)
+			resources.GET("/:id/seats", availabilityController.GetSeatAvailability)
+			resources.GET("/:id/free-slots", availabilityController.GetFreeSlots)
+			resources.GET("/:id/policy", policyController.GetResourcePolicy)
 		}
 
 		// ==================== RUTAS PROTEGIDAS ====================
@@ -66,18 +89,67 @@ func SetupRoutes(router *gin.Engine) {
 
 			// Gestión de perfil
 			protected.PUT("/users/me/password", userController.ChangePassword)
+			protected.GET("/users/me/quota", quotaController.GetMyQuota)
 			protected.GET("/users/:id", userController.GetUserByID)
 			protected.PUT("/users/:id", userController.UpdateUser)
 
 			// ==================== RESERVAS (USUARIOS AUTENTICADOS) ====================
 			bookings := protected.Group("/bookings")
 			{
-				bookings.GET("/my", bookingController.GetMyBookings)             // Mis reservas
-				bookings.GET("/upcoming", bookingController.GetUpcomingBookings) // Próximas reservas
-				bookings.GET("/:id", bookingController.GetBookingByID)           // Detalle de reserva
-				bookings.POST("", bookingController.CreateBooking)               // Crear reserva
-				bookings.PUT("/:id", bookingController.UpdateBooking)            // Actualizar reserva
-				bookings.DELETE("/:id", bookingController.CancelBooking)         // Cancelar reserva
+				bookings.GET("/my", bookingController.GetMyBookings)                             // Mis reservas
+				bookings.GET("/upcoming", bookingController.GetUpcomingBookings)                 // Próximas reservas
+				bookings.GET("/:id", bookingController.GetBookingByID)                           // Detalle de reserva
+				bookings.GET("/:id/cancellation-preview", bookingController.PreviewCancellation) // Costo de cancelar
+				bookings.GET("/:id/history", bookingController.GetBookingHistory)                // Historial de cambios
+				bookings.GET("/:id/check-in-code", bookingController.GetCheckInCode)             // Código QR de check-in
+				bookings.GET("/:id/attendees", bookingController.GetAttendees)                   // Invitados
+				bookings.POST("", bookingController.CreateBooking)                               // Crear reserva
+				bookings.POST("/hold", bookingController.HoldBooking)                            // Retener horario durante el checkout
+				bookings.POST("/:id/hold/complete", bookingController.CompleteHold)              // Completar retención
+				bookings.POST("/:id/check-in", bookingController.CheckIn)                        // Registrar llegada
+				bookings.POST("/:id/end", bookingController.EndBooking)                          // Terminar ahora una reserva en curso
+				bookings.POST("/:id/extend", bookingController.ExtendBooking)                    // Ampliar una reserva en curso
+				bookings.POST("/:id/rsvp", bookingController.RespondInvitation)                  // Responder a una invitación
+				bookings.POST("/:id/transfer", transferController.ProposeTransfer)               // Proponer transferencia a otro usuario
+				bookings.PUT("/:id", bookingController.UpdateBooking)                            // Actualizar reserva
+				bookings.PUT("/:id/attendees", bookingController.SetAttendees)                   // Reemplazar invitados
+				bookings.DELETE("/:id", bookingController.CancelBooking)                         // Cancelar reserva
+			}
+
+			// ==================== RESERVAS MÚLTIPLES ====================
+			reservations := protected.Group("/reservations")
+			{
+				reservations.GET("/my", reservationController.GetMyReservations)     // Mis reservas múltiples
+				reservations.GET("/:id", reservationController.GetReservation)       // Detalle con precio combinado
+				reservations.POST("", reservationController.CreateReservation)       // Reservar varios recursos
+				reservations.PUT("/:id", reservationController.UpdateReservation)    // Modificar en conjunto
+				reservations.DELETE("/:id", reservationController.CancelReservation) // Cancelar en conjunto
+			}
+
+			// ==================== APROBACIONES ====================
+			// Admins y aprobadores designados de cada recurso
+			approvals := protected.Group("/approvals")
+			{
+				approvals.GET("", approvalController.GetApprovalQueue)            // Reservas que puedo aprobar
+				approvals.POST("/:id/approve", approvalController.ApproveBooking) // Aprobar
+				approvals.POST("/:id/reject", approvalController.RejectBooking)   // Rechazar con motivo
+			}
+
+			// ==================== TRANSFERENCIAS ====================
+			transfers := protected.Group("/transfers")
+			{
+				transfers.GET("/my", transferController.GetMyTransfers)            // Propuestas y recibidas
+				transfers.POST("/:id/accept", transferController.AcceptTransfer)   // Aceptar y pasar a ser titular
+				transfers.POST("/:id/decline", transferController.DeclineTransfer) // Rechazar
+				transfers.DELETE("/:id", transferController.CancelTransfer)        // Retirar una propuesta
+			}
+
+			// ==================== LISTA DE ESPERA ====================
+			waitlist := protected.Group("/waitlist")
+			{
+				waitlist.GET("/my", waitlistController.GetMyWaitlist)     // Mis entradas
+				waitlist.POST("", waitlistController.JoinWaitlist)        // Esperar un horario ocupado
+				waitlist.DELETE("/:id", waitlistController.LeaveWaitlist) // Salir de la lista
 			}
 
 			// ==================== RUTAS DE ADMIN ====================
@@ -94,16 +166,52 @@ func SetupRoutes(router *gin.Engine) {
 				admin.PUT("/resources/:id", resourceController.UpdateResource)
 				admin.DELETE("/resources/:id", resourceController.DeleteResource)
 				admin.GET("/resources/stats", resourceController.GetResourceStats)
+				admin.GET("/resources/:id/approvers", resourceController.GetApprovers)
+				admin.PUT("/resources/:id/approvers", resourceController.SetApprovers)
+				admin.PUT("/categories/:category/buffers", resourceController.UpdateCategoryBuffers)
 
 				// Gestión de disponibilidad
 				admin.POST("/resources/:id/availability", availabilityController.CreateAvailability)
+				admin.PUT("/resources/:id/schedule", availabilityController.ReplaceSchedule)
+				admin.GET("/resources/:id/availability/overrides", availabilityController.GetOverrides)
+				admin.POST("/resources/:id/availability/overrides", availabilityController.CreateOverride)
+				admin.PUT("/resources/:id/availability/overrides/:overrideId", availabilityController.UpdateOverride)
+				admin.DELETE("/resources/:id/availability/overrides/:overrideId", availabilityController.DeleteOverride)
 				admin.PUT("/availability/:id", availabilityController.UpdateAvailability)
 				admin.DELETE("/availability/:id", availabilityController.DeleteAvailability)
 
+				// Listas de espera
+				admin.GET("/resources/:id/waitlist", waitlistController.GetResourceWaitlist)
+				admin.PUT("/resources/:id/waitlist/order", waitlistController.ReorderWaitlist)
+
+				// Cierres (feriados, eventos privados)
+				admin.GET("/blackouts", blackoutController.GetAllBlackouts)
+				admin.GET("/blackouts/:id", blackoutController.GetBlackoutByID)
+				admin.POST("/blackouts", blackoutController.CreateBlackout)
+				admin.PUT("/blackouts/:id", blackoutController.UpdateBlackout)
+				admin.DELETE("/blackouts/:id", blackoutController.DeleteBlackout)
+
+				// Políticas de reserva
+				admin.GET("/policies", policyController.GetAllPolicies)
+				admin.PUT("/policies", policyController.SavePolicy)
+				admin.DELETE("/policies/:id", policyController.DeletePolicy)
+
+				// Cuotas de usuarios
+				admin.GET("/quotas", quotaController.GetAllQuotas)
+				admin.PUT("/quotas", quotaController.SaveQuota)
+				admin.DELETE("/quotas/:id", quotaController.DeleteQuota)
+
 				// Gestión de reservas (admin)
 				admin.GET("/bookings", bookingController.GetAllBookings)
 				admin.GET("/bookings/stats", bookingController.GetBookingStats)
 				admin.PATCH("/bookings/:id/status", bookingController.ChangeBookingStatus)
+				admin.POST("/bookings", bookingController.CreateBookingForUser)
+				admin.PUT("/bookings/:id", bookingController.UpdateBookingForUser)
+				admin.DELETE("/bookings/:id", bookingController.CancelBookingForUser)
+
+				// Tareas programadas
+				admin.GET("/jobs", jobController.GetJobs)
+				admin.GET("/jobs/runs", jobController.GetJobRuns)
 			}
 		}
 	}
@@ -4,20 +4,35 @@ import (
 	"Reservify/dto"
 	"Reservify/models"
 	"Reservify/repositories"
+	"Reservify/utils"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 )
 
+// maxFreeSlotRange limita el rango de búsqueda de ventanas libres
+const maxFreeSlotRange = 31 * 24 * time.Hour
+
 type AvailabilityService struct {
 	availabilityRepo *repositories.AvailabilityRepository
 	resourceRepo     *repositories.ResourceRepository
+	bookingRepo      *repositories.BookingRepository
+	blackoutRepo     *repositories.BlackoutRepository
 }
 
-func NewAvailabilityService(availabilityRepo *repositories.AvailabilityRepository, resourceRepo *repositories.ResourceRepository) *AvailabilityService {
+func NewAvailabilityService(
+	availabilityRepo *repositories.AvailabilityRepository,
+	resourceRepo *repositories.ResourceRepository,
+	bookingRepo *repositories.BookingRepository,
+	blackoutRepo *repositories.BlackoutRepository,
+) *AvailabilityService {
 	return &AvailabilityService{
 		availabilityRepo: availabilityRepo,
 		resourceRepo:     resourceRepo,
+		bookingRepo:      bookingRepo,
+		blackoutRepo:     blackoutRepo,
 	}
 }
 
@@ -48,6 +63,268 @@ func (s *AvailabilityService) GetAvailabilityByResource(resourceID uint) ([]dto.
 	return response, nil
 }
 
+// GetEffectiveSchedule obtiene el horario real de un recurso día a día, con excepciones y cierres aplicados.
+// Las fechas (AAAA-MM-DD, inclusive) se interpretan en la zona horaria del recurso.
+func (s *AvailabilityService) GetEffectiveSchedule(resourceID uint, fromDate, toDate string) (*dto.EffectiveScheduleResponse, error) {
+	resource, err := s.resourceRepo.FindByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := resource.Location()
+	from, err := time.ParseInLocation("2006-01-02", fromDate, loc)
+	if err != nil {
+		return nil, errors.New("from debe tener el formato AAAA-MM-DD")
+	}
+	to, err := time.ParseInLocation("2006-01-02", toDate, loc)
+	if err != nil {
+		return nil, errors.New("to debe tener el formato AAAA-MM-DD")
+	}
+	if to.Before(from) {
+		return nil, errors.New("la fecha de fin no puede ser anterior a la fecha de inicio")
+	}
+	end := to.AddDate(0, 0, 1)
+	if end.Sub(from) > maxFreeSlotRange {
+		return nil, errors.New("el rango de búsqueda no puede superar 31 días")
+	}
+
+	schedule, err := loadSchedule(s.availabilityRepo, s.blackoutRepo, resource, from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.EffectiveScheduleResponse{
+		ResourceID: resource.ID,
+		Timezone:   loc.String(),
+		From:       fromDate,
+		To:         toDate,
+		Days:       []dto.EffectiveDay{},
+	}
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		response.Days = append(response.Days, schedule.effectiveDay(day))
+	}
+
+	return response, nil
+}
+
+// GetSeatAvailability calcula las plazas libres de un recurso en un intervalo
+func (s *AvailabilityService) GetSeatAvailability(resourceID uint, start, end time.Time) (*dto.SeatAvailabilityResponse, error) {
+	if !start.Before(end) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	resource, err := s.resourceRepo.FindByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	gap := resource.TurnoverGap()
+	overlapping, err := s.bookingRepo.FindOverlapping(resourceID, start.Add(-gap), end.Add(gap), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// En recursos exclusivos cualquier reserva ocupa el recurso completo
+	booked := utils.PeakLoad(bookingLoads(overlapping, gap), utils.TimeRange{Start: start, End: end})
+	if resource.BookingMode != models.BookingModeShared && booked > 0 {
+		booked = resource.Capacity
+	}
+
+	remaining := resource.Capacity - booked
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &dto.SeatAvailabilityResponse{
+		ResourceID:     resource.ID,
+		BookingMode:    string(resource.BookingMode),
+		Capacity:       resource.Capacity,
+		BookedSeats:    booked,
+		RemainingSeats: remaining,
+		Start:          start,
+		End:            end,
+	}, nil
+}
+
+// GetFreeSlots calcula las ventanas reservables: horarios semanales menos reservas activas
+func (s *AvailabilityService) GetFreeSlots(resourceID uint, from, to time.Time, duration, granularity time.Duration, seats int) (*dto.FreeSlotsResponse, error) {
+	if !from.Before(to) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+	if to.Sub(from) > maxFreeSlotRange {
+		return nil, errors.New("el rango de búsqueda no puede superar 31 días")
+	}
+	if seats <= 0 {
+		seats = 1
+	}
+
+	resource, err := s.resourceRepo.FindByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if seats > resource.Capacity {
+		return nil, fmt.Errorf("el recurso admite como máximo %d plazas", resource.Capacity)
+	}
+
+	// No tiene sentido ofrecer ventanas en el pasado
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+
+	response := &dto.FreeSlotsResponse{
+		ResourceID:      resource.ID,
+		Timezone:        resource.Location().String(),
+		From:            from,
+		To:              to,
+		DurationMinutes: int(duration.Minutes()),
+		Seats:           seats,
+		Slots:           []dto.FreeSlot{},
+	}
+	if !from.Before(to) {
+		return response, nil
+	}
+
+	schedule, err := loadSchedule(s.availabilityRepo, s.blackoutRepo, resource, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	gap := resource.TurnoverGap()
+	bookings, err := s.bookingRepo.FindOverlapping(resourceID, from.Add(-gap), to.Add(gap), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo se usan los intervalos ocupados; nunca se expone quién reservó
+	loc := resource.Location()
+	window := utils.TimeRange{Start: from.In(loc), End: to.In(loc)}
+	occupied := occupiedRanges(resource, bookings, seats)
+
+	for _, opening := range schedule.openRanges(from, to) {
+		opening = clipRange(opening, window)
+		if !opening.Start.Before(opening.End) {
+			continue
+		}
+
+		for _, free := range utils.SubtractRanges(opening, occupied) {
+			// La granularidad se alinea con la medianoche local del recurso
+			free = utils.TimeRange{Start: free.Start.In(loc), End: free.End.In(loc)}
+			aligned, ok := utils.AlignRange(free, granularity)
+			if !ok || aligned.End.Sub(aligned.Start) < duration {
+				continue
+			}
+			response.Slots = append(response.Slots, dto.FreeSlot{Start: aligned.Start, End: aligned.End})
+		}
+	}
+
+	return response, nil
+}
+
+// SearchAvailableResources busca recursos abiertos y libres en un intervalo usando pocas consultas
+func (s *AvailabilityService) SearchAvailableResources(req *dto.ResourceSearchRequest) ([]dto.ResourceSearchResult, error) {
+	if !req.Start.Before(req.End) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	seats := req.MinCapacity
+	if seats <= 0 {
+		seats = 1
+	}
+
+	// 1. Recursos que cumplen los filtros, ya ordenados por mejor ajuste
+	resources, err := s.resourceRepo.FindCandidates(repositories.ResourceSearchFilters{
+		Category:        req.Category,
+		MinCapacity:     seats,
+		MaxPricePerHour: req.MaxPricePerHour,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := []dto.ResourceSearchResult{}
+	if len(resources) == 0 {
+		return results, nil
+	}
+
+	ids := make([]uint, 0, len(resources))
+	for _, resource := range resources {
+		ids = append(ids, resource.ID)
+	}
+
+	// 2. Horarios de todos los candidatos
+	slots, err := s.availabilityRepo.FindByResourceIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	slotsByResource := make(map[uint][]models.AvailabilitySlot)
+	for _, slot := range slots {
+		slotsByResource[slot.ResourceID] = append(slotsByResource[slot.ResourceID], slot)
+	}
+
+	// 3. Excepciones de horario para las fechas del intervalo
+	fromDate, toDate := overrideDateRange(req.Start, req.End)
+	overrides, err := s.availabilityRepo.FindOverridesByResourceIDs(ids, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Cierres que afectan a cualquiera de los candidatos
+	blackouts, err := s.blackoutRepo.FindInRange(resources, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Reservas activas que se solapan con el intervalo
+	// La ventana se amplía con el mayor margen; cada recurso se evalúa después con el suyo
+	var maxGap time.Duration
+	for i := range resources {
+		if gap := resources[i].TurnoverGap(); gap > maxGap {
+			maxGap = gap
+		}
+	}
+	bookings, err := s.bookingRepo.FindOverlappingForResources(ids, req.Start.Add(-maxGap), req.End.Add(maxGap))
+	if err != nil {
+		return nil, err
+	}
+	bookingsByResource := make(map[uint][]models.Booking)
+	for _, booking := range bookings {
+		bookingsByResource[booking.ResourceID] = append(bookingsByResource[booking.ResourceID], booking)
+	}
+
+	window := utils.TimeRange{Start: req.Start, End: req.End}
+	for _, resource := range resources {
+		schedule := newResourceSchedule(&resource, slotsByResource[resource.ID], overrides, blackouts)
+		if err := schedule.check(req.Start, req.End); err != nil {
+			continue
+		}
+
+		booked := utils.PeakLoad(bookingLoads(bookingsByResource[resource.ID], resource.TurnoverGap()), window)
+		remaining := resource.Capacity
+		if resource.BookingMode == models.BookingModeShared {
+			remaining -= booked
+		} else if booked > 0 {
+			remaining = 0
+		}
+		if remaining < seats {
+			continue
+		}
+
+		results = append(results, dto.ResourceSearchResult{
+			ID:             resource.ID,
+			Name:           resource.Name,
+			Capacity:       resource.Capacity,
+			PricePerHour:   resource.PricePerHour,
+			Category:       resource.Category,
+			ImageURL:       resource.ImageURL,
+			BookingMode:    string(resource.BookingMode),
+			Timezone:       resource.Timezone,
+			RemainingSeats: remaining,
+		})
+	}
+
+	return results, nil
+}
+
 // CreateAvailability crea un nuevo horario de disponibilidad
 func (s *AvailabilityService) CreateAvailability(resourceID uint, req *dto.CreateAvailabilityRequest) (*dto.AvailabilityResponse, error) {
 	// Verificar que el recurso existe
@@ -149,6 +426,303 @@ func (s *AvailabilityService) DeleteAvailability(id uint) error {
 	return s.availabilityRepo.Delete(id)
 }
 
+// ReplaceSchedule reemplaza la plantilla semanal completa de un recurso en una sola transacción.
+// Informa las reservas futuras que quedarían fuera del nuevo horario; con dryRun no guarda nada.
+func (s *AvailabilityService) ReplaceSchedule(resourceID uint, req *dto.ReplaceScheduleRequest, dryRun bool) (*dto.ReplaceScheduleResponse, error) {
+	resource, err := s.resourceRepo.FindByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := buildWeeklySlots(resourceID, req.Slots)
+	if err != nil {
+		return nil, err
+	}
+
+	outside, err := s.bookingsOutsideSchedule(resource, slots)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		err = s.availabilityRepo.WithTransaction(func(txRepo *repositories.AvailabilityRepository) error {
+			if err := txRepo.DeleteByResourceID(resourceID); err != nil {
+				return err
+			}
+			for i := range slots {
+				if err := txRepo.Create(&slots[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.New("error al reemplazar los horarios")
+		}
+	}
+
+	response := &dto.ReplaceScheduleResponse{
+		ResourceID:      resourceID,
+		DryRun:          dryRun,
+		Slots:           make([]dto.AvailabilityResponse, 0, len(slots)),
+		OutsideBookings: outside,
+	}
+	for _, slot := range slots {
+		response.Slots = append(response.Slots, dto.AvailabilityResponse{
+			ID:         slot.ID,
+			ResourceID: slot.ResourceID,
+			DayOfWeek:  string(slot.DayOfWeek),
+			StartTime:  slot.StartTime,
+			EndTime:    slot.EndTime,
+			CreatedAt:  slot.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// bookingsOutsideSchedule busca las reservas futuras que no encajan en la nueva plantilla.
+// Las excepciones por fecha se mantienen; los cierres no cuentan porque no dependen de la plantilla.
+func (s *AvailabilityService) bookingsOutsideSchedule(resource *models.Resource, slots []models.AvailabilitySlot) ([]dto.ScheduleConflict, error) {
+	outside := []dto.ScheduleConflict{}
+
+	bookings, err := s.bookingRepo.FindUpcomingByResource(resource.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) == 0 {
+		return outside, nil
+	}
+
+	last := bookings[0].EndDatetime
+	for _, booking := range bookings {
+		if booking.EndDatetime.After(last) {
+			last = booking.EndDatetime
+		}
+	}
+
+	fromDate, toDate := overrideDateRange(bookings[0].StartDatetime, last)
+	overrides, err := s.availabilityRepo.FindOverrides(resource.ID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := newResourceSchedule(resource, slots, overrides, nil)
+	for i := range bookings {
+		if err := schedule.check(bookings[i].StartDatetime, bookings[i].EndDatetime); err != nil {
+			outside = append(outside, dto.ScheduleConflict{
+				AffectedBooking: mapAffectedBooking(&bookings[i]),
+				Reason:          err.Error(),
+			})
+		}
+	}
+
+	return outside, nil
+}
+
+// buildWeeklySlots valida la plantilla semanal completa y une las franjas contiguas de cada día
+func buildWeeklySlots(resourceID uint, reqs []dto.CreateAvailabilityRequest) ([]models.AvailabilitySlot, error) {
+	reference := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	byDay := make(map[models.DayOfWeek][]models.AvailabilitySlot)
+
+	for _, req := range reqs {
+		startTime := normalizeTime(req.StartTime)
+		endTime := normalizeTime(req.EndTime)
+
+		for _, value := range []string{startTime, endTime} {
+			if _, err := utils.ClockOn(reference, value); err != nil {
+				return nil, fmt.Errorf("hora inválida: %s", value)
+			}
+		}
+		if startTime >= endTime {
+			return nil, fmt.Errorf("la hora de inicio debe ser menor que la hora de fin (%s %s - %s)", req.DayOfWeek, startTime, endTime)
+		}
+
+		day := models.DayOfWeek(req.DayOfWeek)
+		byDay[day] = append(byDay[day], models.AvailabilitySlot{
+			ResourceID: resourceID,
+			DayOfWeek:  day,
+			StartTime:  startTime,
+			EndTime:    endTime,
+		})
+	}
+
+	var slots []models.AvailabilitySlot
+	week := []models.DayOfWeek{models.Monday, models.Tuesday, models.Wednesday, models.Thursday, models.Friday, models.Saturday, models.Sunday}
+	for _, day := range week {
+		daySlots := byDay[day]
+		sort.Slice(daySlots, func(i, j int) bool { return daySlots[i].StartTime < daySlots[j].StartTime })
+
+		var merged []models.AvailabilitySlot
+		for _, slot := range daySlots {
+			if n := len(merged); n > 0 {
+				current := &merged[n-1]
+				if slot.StartTime < current.EndTime {
+					return nil, fmt.Errorf("los horarios del %s se solapan: %s - %s y %s - %s",
+						day, current.StartTime, current.EndTime, slot.StartTime, slot.EndTime)
+				}
+				// Las franjas contiguas se guardan como una sola
+				if slot.StartTime == current.EndTime {
+					current.EndTime = slot.EndTime
+					continue
+				}
+			}
+			merged = append(merged, slot)
+		}
+		slots = append(slots, merged...)
+	}
+
+	return slots, nil
+}
+
+// GetOverrides obtiene las excepciones de horario de un recurso, opcionalmente filtradas por fechas
+func (s *AvailabilityService) GetOverrides(resourceID uint, fromDate, toDate string) ([]dto.AvailabilityOverrideResponse, error) {
+	if _, err := s.resourceRepo.FindByID(resourceID); err != nil {
+		return nil, err
+	}
+
+	if fromDate == "" {
+		fromDate = "0000-01-01"
+	}
+	if toDate == "" {
+		toDate = "9999-12-31"
+	}
+
+	overrides, err := s.availabilityRepo.FindOverrides(resourceID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AvailabilityOverrideResponse, 0, len(overrides))
+	for i := range overrides {
+		response = append(response, *mapOverrideResponse(&overrides[i]))
+	}
+
+	return response, nil
+}
+
+// CreateOverride crea una franja especial para una fecha concreta
+func (s *AvailabilityService) CreateOverride(resourceID uint, req *dto.AvailabilityOverrideRequest) (*dto.AvailabilityOverrideResponse, error) {
+	if _, err := s.resourceRepo.FindByID(resourceID); err != nil {
+		return nil, err
+	}
+
+	override := &models.AvailabilityOverride{ResourceID: resourceID}
+	if err := s.applyOverrideRequest(override, req, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.availabilityRepo.CreateOverride(override); err != nil {
+		return nil, errors.New("error al crear la excepción de horario")
+	}
+
+	return mapOverrideResponse(override), nil
+}
+
+// UpdateOverride actualiza una excepción de horario del recurso
+func (s *AvailabilityService) UpdateOverride(resourceID, id uint, req *dto.AvailabilityOverrideRequest) (*dto.AvailabilityOverrideResponse, error) {
+	override, err := s.availabilityRepo.FindOverrideByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if override.ResourceID != resourceID {
+		return nil, errors.New("excepción de horario no encontrada")
+	}
+
+	if err := s.applyOverrideRequest(override, req, &id); err != nil {
+		return nil, err
+	}
+
+	if err := s.availabilityRepo.UpdateOverride(override); err != nil {
+		return nil, errors.New("error al actualizar la excepción de horario")
+	}
+
+	return mapOverrideResponse(override), nil
+}
+
+// DeleteOverride elimina una excepción de horario del recurso
+func (s *AvailabilityService) DeleteOverride(resourceID, id uint) error {
+	override, err := s.availabilityRepo.FindOverrideByID(id)
+	if err != nil {
+		return err
+	}
+	if override.ResourceID != resourceID {
+		return errors.New("excepción de horario no encontrada")
+	}
+
+	return s.availabilityRepo.DeleteOverride(id)
+}
+
+// applyOverrideRequest valida la franja y la vuelca sobre la excepción
+func (s *AvailabilityService) applyOverrideRequest(override *models.AvailabilityOverride, req *dto.AvailabilityOverrideRequest, excludeID *uint) error {
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return errors.New("date debe tener el formato AAAA-MM-DD")
+	}
+
+	// Normalizar tiempos (agregar :00 si solo tiene HH:MM)
+	startTime := normalizeTime(req.StartTime)
+	endTime := normalizeTime(req.EndTime)
+
+	// Validar que start_time < end_time
+	if startTime >= endTime {
+		return errors.New("la hora de inicio debe ser menor que la hora de fin")
+	}
+
+	// Verificar solapamiento con otras franjas del mismo día
+	overlap, err := s.availabilityRepo.CheckOverrideOverlap(override.ResourceID, req.Date, startTime, endTime, excludeID)
+	if err != nil {
+		return err
+	}
+	if overlap {
+		return errors.New("la franja se solapa con otra excepción del mismo día")
+	}
+
+	override.Date = req.Date
+	override.StartTime = startTime
+	override.EndTime = endTime
+	override.Reason = req.Reason
+	return nil
+}
+
+func mapOverrideResponse(override *models.AvailabilityOverride) *dto.AvailabilityOverrideResponse {
+	return &dto.AvailabilityOverrideResponse{
+		ID:         override.ID,
+		ResourceID: override.ResourceID,
+		Date:       override.Date,
+		StartTime:  override.StartTime,
+		EndTime:    override.EndTime,
+		Reason:     override.Reason,
+		CreatedAt:  override.CreatedAt,
+	}
+}
+
+// occupiedRanges calcula los tramos en los que no caben las plazas pedidas, márgenes incluidos
+func occupiedRanges(resource *models.Resource, bookings []models.Booking, seats int) []utils.TimeRange {
+	loads := bookingLoads(bookings, resource.TurnoverGap())
+
+	if resource.BookingMode != models.BookingModeShared {
+		// En recursos exclusivos cualquier reserva bloquea el tramo completo
+		var ranges []utils.TimeRange
+		for _, load := range loads {
+			ranges = append(ranges, load.TimeRange)
+		}
+		return utils.MergeRanges(ranges)
+	}
+
+	return utils.OverloadedRanges(loads, resource.Capacity-seats)
+}
+
+// clipRange recorta r para que quede dentro de window
+func clipRange(r, window utils.TimeRange) utils.TimeRange {
+	if r.Start.Before(window.Start) {
+		r.Start = window.Start
+	}
+	if r.End.After(window.End) {
+		r.End = window.End
+	}
+	return r
+}
+
 // normalizeTime normaliza el formato de tiempo (agrega :00 si es necesario)
 func normalizeTime(timeStr string) string {
 	// Si ya tiene formato HH:MM:SS, devolverlo tal cual
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"Reservify/utils"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type BlackoutService struct {
+	blackoutRepo     *repositories.BlackoutRepository
+	resourceRepo     *repositories.ResourceRepository
+	bookingRepo      *repositories.BookingRepository
+	notificationRepo *repositories.NotificationRepository
+}
+
+func NewBlackoutService(
+	blackoutRepo *repositories.BlackoutRepository,
+	resourceRepo *repositories.ResourceRepository,
+	bookingRepo *repositories.BookingRepository,
+	notificationRepo *repositories.NotificationRepository,
+) *BlackoutService {
+	return &BlackoutService{
+		blackoutRepo:     blackoutRepo,
+		resourceRepo:     resourceRepo,
+		bookingRepo:      bookingRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// GetAllBlackouts obtiene todos los cierres con paginación
+func (s *BlackoutService) GetAllBlackouts(params utils.PaginationParams) ([]dto.BlackoutResponse, int64, error) {
+	blackouts, total, err := s.blackoutRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]dto.BlackoutResponse, 0, len(blackouts))
+	for i := range blackouts {
+		response = append(response, *mapBlackoutResponse(&blackouts[i]))
+	}
+
+	return response, total, nil
+}
+
+// GetBlackoutByID obtiene un cierre junto con las reservas activas que coinciden con él
+func (s *BlackoutService) GetBlackoutByID(id uint) (*dto.BlackoutResponse, error) {
+	blackout, err := s.blackoutRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := s.findAffectedBookings(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapBlackoutResponse(blackout)
+	response.AffectedBookings = mapAffectedBookings(affected)
+	return response, nil
+}
+
+// CreateBlackout crea un cierre e informa (o cancela) las reservas afectadas
+func (s *BlackoutService) CreateBlackout(adminID uint, req *dto.BlackoutRequest) (*dto.BlackoutResponse, error) {
+	blackout := &models.Blackout{}
+	if err := s.applyRequest(blackout, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.blackoutRepo.Create(blackout); err != nil {
+		return nil, errors.New("error al crear el cierre")
+	}
+
+	return s.reportAffected(blackout, req.CancelAffected, adminID)
+}
+
+// UpdateBlackout actualiza un cierre e informa (o cancela) las reservas afectadas
+func (s *BlackoutService) UpdateBlackout(id uint, adminID uint, req *dto.BlackoutRequest) (*dto.BlackoutResponse, error) {
+	blackout, err := s.blackoutRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(blackout, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.blackoutRepo.Update(blackout); err != nil {
+		return nil, errors.New("error al actualizar el cierre")
+	}
+
+	return s.reportAffected(blackout, req.CancelAffected, adminID)
+}
+
+// DeleteBlackout elimina un cierre
+func (s *BlackoutService) DeleteBlackout(id uint) error {
+	return s.blackoutRepo.Delete(id)
+}
+
+// applyRequest valida la petición y la vuelca sobre el cierre
+func (s *BlackoutService) applyRequest(blackout *models.Blackout, req *dto.BlackoutRequest) error {
+	if req.ResourceID != nil && req.Category != "" {
+		return errors.New("indica un recurso o una categoría, no ambos")
+	}
+	if req.ResourceID != nil {
+		if _, err := s.resourceRepo.FindByID(*req.ResourceID); err != nil {
+			return err
+		}
+	}
+
+	blackout.ResourceID = req.ResourceID
+	blackout.Category = req.Category
+	blackout.AllDay = req.AllDay
+	blackout.Reason = req.Reason
+
+	if req.AllDay {
+		endDate := req.EndDate
+		if endDate == "" {
+			endDate = req.StartDate
+		}
+		start, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return errors.New("start_date debe tener el formato AAAA-MM-DD")
+		}
+		end, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return errors.New("end_date debe tener el formato AAAA-MM-DD")
+		}
+		if end.Before(start) {
+			return errors.New("la fecha de fin no puede ser anterior a la fecha de inicio")
+		}
+
+		blackout.StartDate = req.StartDate
+		blackout.EndDate = endDate
+		blackout.StartDatetime = nil
+		blackout.EndDatetime = nil
+		return nil
+	}
+
+	if req.StartDatetime == nil || req.EndDatetime == nil {
+		return errors.New("start_datetime y end_datetime son obligatorios si el cierre no es de día completo")
+	}
+	if !req.StartDatetime.Before(*req.EndDatetime) {
+		return errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	start := req.StartDatetime.UTC()
+	end := req.EndDatetime.UTC()
+	blackout.StartDate = ""
+	blackout.EndDate = ""
+	blackout.StartDatetime = &start
+	blackout.EndDatetime = &end
+	return nil
+}
+
+// reportAffected arma la respuesta con las reservas afectadas y, si se pide, las cancela a nombre
+// del admin avisando al usuario
+func (s *BlackoutService) reportAffected(blackout *models.Blackout, cancel bool, adminID uint) (*dto.BlackoutResponse, error) {
+	affected, err := s.findAffectedBookings(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapBlackoutResponse(blackout)
+	if !cancel || len(affected) == 0 {
+		response.AffectedBookings = mapAffectedBookings(affected)
+		return response, nil
+	}
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		for i := range affected {
+			before := affected[i]
+			affected[i].Status = models.StatusCancelled
+			if err := txRepo.Update(&affected[i]); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, &before, &affected[i], &adminID, "cierre del recurso: "+blackout.Reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("error al cancelar las reservas afectadas")
+	}
+
+	// Las notificaciones no deshacen la cancelación si fallan
+	for i := range affected {
+		bookingID := affected[i].ID
+		_ = s.notificationRepo.Create(&models.Notification{
+			UserID:    affected[i].UserID,
+			BookingID: &bookingID,
+			Message:   fmt.Sprintf("Tu reserva #%d fue cancelada por un cierre del recurso: %s", bookingID, blackout.Reason),
+		})
+	}
+
+	response.AffectedBookings = mapAffectedBookings(affected)
+	response.CancelledCount = len(affected)
+	return response, nil
+}
+
+// findAffectedBookings busca las reservas activas que se solapan con el cierre en la zona horaria de cada recurso
+func (s *BlackoutService) findAffectedBookings(blackout *models.Blackout) ([]models.Booking, error) {
+	resources, err := s.resourceRepo.FindInScope(blackout.ResourceID, blackout.Category)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	closures := make(map[uint]utils.TimeRange, len(resources))
+	ids := make([]uint, 0, len(resources))
+	var window utils.TimeRange
+	for i := range resources {
+		r, ok := blackoutRange(blackout, resources[i].Location())
+		if !ok {
+			continue
+		}
+		closures[resources[i].ID] = r
+		ids = append(ids, resources[i].ID)
+		if window.Start.IsZero() || r.Start.Before(window.Start) {
+			window.Start = r.Start
+		}
+		if r.End.After(window.End) {
+			window.End = r.End
+		}
+	}
+
+	// No interesan las reservas que ya terminaron
+	if now := time.Now(); window.Start.Before(now) {
+		window.Start = now
+	}
+	if !window.Start.Before(window.End) {
+		return nil, nil
+	}
+
+	bookings, err := s.bookingRepo.FindOverlappingForResources(ids, window.Start, window.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var affected []models.Booking
+	for _, booking := range bookings {
+		closure := closures[booking.ResourceID]
+		if booking.StartDatetime.Before(closure.End) && booking.EndDatetime.After(closure.Start) {
+			affected = append(affected, booking)
+		}
+	}
+	return affected, nil
+}
+
+func mapBlackoutResponse(blackout *models.Blackout) *dto.BlackoutResponse {
+	return &dto.BlackoutResponse{
+		ID:            blackout.ID,
+		ResourceID:    blackout.ResourceID,
+		Category:      blackout.Category,
+		AllDay:        blackout.AllDay,
+		StartDate:     blackout.StartDate,
+		EndDate:       blackout.EndDate,
+		StartDatetime: blackout.StartDatetime,
+		EndDatetime:   blackout.EndDatetime,
+		Reason:        blackout.Reason,
+		CreatedAt:     blackout.CreatedAt,
+	}
+}
+
+func mapAffectedBookings(bookings []models.Booking) []dto.AffectedBooking {
+	response := make([]dto.AffectedBooking, 0, len(bookings))
+	for i := range bookings {
+		response = append(response, mapAffectedBooking(&bookings[i]))
+	}
+	return response
+}
+
+func mapAffectedBooking(booking *models.Booking) dto.AffectedBooking {
+	return dto.AffectedBooking{
+		ID:            booking.ID,
+		UserID:        booking.UserID,
+		ResourceID:    booking.ResourceID,
+		StartDatetime: booking.StartDatetime,
+		EndDatetime:   booking.EndDatetime,
+		Status:        string(booking.Status),
+	}
+}
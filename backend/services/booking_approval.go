@@ -0,0 +1,137 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GetApprovalQueue obtiene las reservas pendientes que el usuario puede aprobar o rechazar.
+// Un admin ve todas; un aprobador designado, las de sus recursos en modo "approvers".
+func (s *BookingService) GetApprovalQueue(userID uint, isAdmin bool) ([]dto.BookingListResponse, error) {
+	var resourceIDs []uint
+	if !isAdmin {
+		ids, err := s.resourceRepo.FindApprovableIDs(userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return []dto.BookingListResponse{}, nil
+		}
+		resourceIDs = ids
+	}
+
+	bookings, err := s.bookingRepo.FindAwaitingApproval(resourceIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Un aprobador no decide sobre sus propias reservas
+	queue := make([]models.Booking, 0, len(bookings))
+	for _, booking := range bookings {
+		if isAdmin || booking.UserID != userID {
+			queue = append(queue, booking)
+		}
+	}
+
+	response := s.mapToListResponse(queue)
+	if response == nil {
+		response = []dto.BookingListResponse{}
+	}
+	return response, nil
+}
+
+// ApproveBooking confirma una reserva pendiente registrando quién la aprobó
+func (s *BookingService) ApproveBooking(id uint, deciderID uint, isAdmin bool, req *dto.BookingDecisionRequest) (*dto.BookingResponse, error) {
+	return s.decideBooking(id, deciderID, isAdmin, models.StatusConfirmed, strings.TrimSpace(req.Reason))
+}
+
+// RejectBooking cancela una reserva pendiente registrando quién la rechazó y el motivo
+func (s *BookingService) RejectBooking(id uint, deciderID uint, isAdmin bool, req *dto.BookingDecisionRequest) (*dto.BookingResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("indica el motivo del rechazo")
+	}
+	return s.decideBooking(id, deciderID, isAdmin, models.StatusCancelled, reason)
+}
+
+// decideBooking aplica la decisión si el usuario puede tomarla y la reserva sigue pendiente,
+// avisa al titular y, si se rechazó, ofrece el horario a la lista de espera
+func (s *BookingService) decideBooking(id uint, deciderID uint, isAdmin bool, status models.BookingStatus, note string) (*dto.BookingResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkApprover(booking, deciderID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	if booking.Status != models.StatusPending || booking.IsHold {
+		return nil, errors.New("la reserva no está pendiente de aprobación")
+	}
+
+	// Otra decisión o la expiración pueden adelantarse: solo se aplica si sigue pendiente
+	var decided bool
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		var err error
+		decided, err = txRepo.Decide(booking.ID, status, deciderID, note, time.Now())
+		if err != nil || !decided {
+			return err
+		}
+
+		after := *booking
+		after.Status = status
+		return recordEvent(txRepo, booking, &after, &deciderID, note)
+	})
+	if err != nil {
+		return nil, errors.New("error al registrar la decisión")
+	}
+	if !decided {
+		return nil, errors.New("la reserva ya no está pendiente de aprobación")
+	}
+
+	message := fmt.Sprintf("Tu reserva #%d en %s fue aprobada", booking.ID, booking.Resource.Name)
+	if status == models.StatusCancelled {
+		message = fmt.Sprintf("Tu reserva #%d en %s fue rechazada: %s", booking.ID, booking.Resource.Name, note)
+	}
+	_ = s.notificationRepo.Create(&models.Notification{
+		UserID:    booking.UserID,
+		BookingID: &booking.ID,
+		Message:   message,
+	})
+
+	if status == models.StatusCancelled {
+		s.promoteWaitlist(&booking.Resource, booking.StartDatetime, booking.EndDatetime)
+	}
+
+	return s.GetBookingByID(booking.ID, deciderID, true)
+}
+
+// checkApprover verifica que el usuario pueda decidir sobre la reserva según el modo de aprobación del recurso
+func (s *BookingService) checkApprover(booking *models.Booking, userID uint, isAdmin bool) error {
+	if isAdmin {
+		return nil
+	}
+
+	if booking.Resource.ApprovalMode != models.ApprovalApprovers {
+		return errors.New("las reservas de este recurso solo las aprueba un administrador")
+	}
+
+	approver, err := s.resourceRepo.IsApprover(booking.ResourceID, userID)
+	if err != nil {
+		return err
+	}
+	if !approver {
+		return errors.New("no eres aprobador de este recurso")
+	}
+
+	if booking.UserID == userID {
+		return errors.New("no puedes aprobar tus propias reservas")
+	}
+	return nil
+}
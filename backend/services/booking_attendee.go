@@ -0,0 +1,252 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GetAttendees obtiene los invitados de una reserva; pueden verlos el titular, un admin y los propios invitados
+func (s *BookingService) GetAttendees(id uint, userID uint, isAdmin bool) ([]dto.AttendeeResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCanView(booking, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	attendees, err := s.bookingRepo.FindAttendees(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapAttendees(attendees), nil
+}
+
+// SetAttendees reemplaza la lista de invitados de una reserva activa. Los invitados que siguen en la
+// lista conservan su respuesta; el titular puede anotar la de los externos, que no tienen cuenta.
+// El total de personas no puede superar la capacidad del recurso.
+func (s *BookingService) SetAttendees(id uint, userID uint, isAdmin bool, req *dto.BookingAttendeesRequest) ([]dto.AttendeeResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para editar esta reserva")
+	}
+
+	if err := checkAttendable(booking); err != nil {
+		return nil, err
+	}
+
+	current, err := s.bookingRepo.FindAttendees(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendees, invited, err := s.buildAttendees(booking, current, req.Attendees)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := attendeeLimit(booking); models.Headcount(attendees) > limit {
+		return nil, fmt.Errorf("la reserva admite como máximo %d personas contando al titular", limit)
+	}
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		return txRepo.ReplaceAttendees(booking.ID, attendees)
+	})
+	if err != nil {
+		return nil, errors.New("error al guardar los invitados")
+	}
+
+	for _, inviteeID := range invited {
+		_ = s.notificationRepo.Create(&models.Notification{
+			UserID:    inviteeID,
+			BookingID: &booking.ID,
+			Message: fmt.Sprintf("%s te invitó a su reserva #%d en %s", booking.User.FullName, booking.ID,
+				booking.Resource.Name),
+		})
+	}
+
+	return s.GetAttendees(booking.ID, userID, isAdmin)
+}
+
+// RespondInvitation registra la respuesta del usuario autenticado a su invitación y avisa al titular
+func (s *BookingService) RespondInvitation(id uint, userID uint, req *dto.RSVPRequest) (*dto.AttendeeResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	attendee, err := s.bookingRepo.FindAttendee(booking.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAttendable(booking); err != nil {
+		return nil, err
+	}
+
+	status := models.RSVPStatus(req.Status)
+	if attendee.RSVPStatus == models.RSVPDeclined && status != models.RSVPDeclined {
+		// Quien rechazó liberó su plaza: volver a aceptar exige que siga habiendo sitio
+		attendees, err := s.bookingRepo.FindAttendees(booking.ID)
+		if err != nil {
+			return nil, err
+		}
+		if models.Headcount(attendees) >= attendeeLimit(booking) {
+			return nil, errors.New("la reserva ya está completa")
+		}
+	}
+
+	attendee.Respond(status, time.Now())
+	if err := s.bookingRepo.UpdateAttendee(attendee); err != nil {
+		return nil, errors.New("error al registrar la respuesta")
+	}
+
+	_ = s.notificationRepo.Create(&models.Notification{
+		UserID:    booking.UserID,
+		BookingID: &booking.ID,
+		Message:   fmt.Sprintf("Un invitado respondió \"%s\" a tu reserva #%d en %s", status, booking.ID, booking.Resource.Name),
+	})
+
+	response := mapAttendee(*attendee)
+	return &response, nil
+}
+
+// buildAttendees valida la lista pedida y la combina con los invitados actuales para conservar sus
+// respuestas. Devuelve también los usuarios registrados que se invitan por primera vez.
+func (s *BookingService) buildAttendees(booking *models.Booking, current []models.BookingAttendee, requested []dto.AttendeeRequest) ([]models.BookingAttendee, []uint, error) {
+	existing := make(map[string]models.BookingAttendee, len(current))
+	for _, attendee := range current {
+		existing[attendeeKey(attendee.UserID, attendee.Email)] = attendee
+	}
+
+	seen := make(map[string]bool, len(requested))
+	attendees := make([]models.BookingAttendee, 0, len(requested))
+	var invited []uint
+	for _, item := range requested {
+		email := strings.ToLower(strings.TrimSpace(item.Email))
+		if (item.UserID == nil) == (email == "") {
+			return nil, nil, errors.New("cada invitado debe indicar un usuario registrado o un email, no ambos")
+		}
+
+		var userID *uint
+		if item.UserID != nil {
+			if *item.UserID == booking.UserID {
+				return nil, nil, errors.New("el titular de la reserva no puede ser invitado")
+			}
+			if _, err := s.userRepo.FindByID(*item.UserID); err != nil {
+				return nil, nil, fmt.Errorf("el usuario %d no existe", *item.UserID)
+			}
+			userID = item.UserID
+			email = ""
+		}
+
+		key := attendeeKey(userID, email)
+		if seen[key] {
+			return nil, nil, errors.New("hay invitados repetidos en la lista")
+		}
+		seen[key] = true
+
+		attendee, found := existing[key]
+		if !found {
+			attendee = models.BookingAttendee{UserID: userID, Email: email, RSVPStatus: models.RSVPPending}
+			if userID != nil {
+				invited = append(invited, *userID)
+			}
+		}
+		attendee.Name = strings.TrimSpace(item.Name)
+
+		// Los registrados responden ellos mismos; la respuesta de los externos la anota el titular
+		if item.RSVPStatus != "" {
+			if userID != nil {
+				return nil, nil, errors.New("los usuarios registrados responden a la invitación ellos mismos")
+			}
+			if status := models.RSVPStatus(item.RSVPStatus); status != attendee.RSVPStatus {
+				attendee.Respond(status, time.Now())
+			}
+		}
+
+		attendees = append(attendees, attendee)
+	}
+
+	return attendees, invited, nil
+}
+
+// checkCanView verifica que el usuario pueda ver la reserva: el titular, un admin o un invitado
+func (s *BookingService) checkCanView(booking *models.Booking, userID uint, isAdmin bool) error {
+	if isAdmin || booking.UserID == userID {
+		return nil
+	}
+
+	attending, err := s.bookingRepo.IsAttendee(booking.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !attending {
+		return errors.New("no tienes permisos para ver esta reserva")
+	}
+	return nil
+}
+
+// checkAttendable verifica que la reserva siga activa para gestionar sus invitados
+func checkAttendable(booking *models.Booking) error {
+	if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
+		return errors.New("solo se gestionan los invitados de reservas pendientes o confirmadas")
+	}
+	if !time.Now().Before(booking.EndDatetime) {
+		return errors.New("la reserva ya terminó")
+	}
+	return nil
+}
+
+// attendeeLimit es el máximo de personas de una reserva contando al titular: la capacidad del
+// recurso o, en recursos compartidos, las plazas que ocupa la reserva
+func attendeeLimit(booking *models.Booking) int {
+	if booking.Resource.BookingMode == models.BookingModeShared {
+		return booking.Seats
+	}
+	return booking.Resource.Capacity
+}
+
+// attendeeKey identifica a un invitado por su usuario o, si es externo, por su email
+func attendeeKey(userID *uint, email string) string {
+	if userID != nil {
+		return fmt.Sprintf("user:%d", *userID)
+	}
+	return "email:" + email
+}
+
+func mapAttendees(attendees []models.BookingAttendee) []dto.AttendeeResponse {
+	response := make([]dto.AttendeeResponse, 0, len(attendees))
+	for _, attendee := range attendees {
+		response = append(response, mapAttendee(attendee))
+	}
+	return response
+}
+
+func mapAttendee(attendee models.BookingAttendee) dto.AttendeeResponse {
+	response := dto.AttendeeResponse{
+		ID:          attendee.ID,
+		UserID:      attendee.UserID,
+		Email:       attendee.Email,
+		Name:        attendee.Name,
+		External:    attendee.IsExternal(),
+		RSVPStatus:  string(attendee.RSVPStatus),
+		RespondedAt: attendee.RespondedAt,
+	}
+	// De los registrados se muestran los datos de su cuenta
+	if attendee.User != nil {
+		response.Email = attendee.User.Email
+		response.Name = attendee.User.FullName
+	}
+	return response
+}
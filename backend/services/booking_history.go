@@ -0,0 +1,74 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+)
+
+// Motivos registrados en el historial para los cambios que hace el sistema
+const (
+	reasonWaitlistPromoted = "se liberó el horario de la lista de espera"
+	reasonNoCheckIn        = "no se registró la llegada"
+)
+
+// GetBookingHistory obtiene el historial de cambios de una reserva (solo el titular o un admin)
+func (s *BookingService) GetBookingHistory(id uint, userID uint, isAdmin bool) ([]dto.BookingEventResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos: solo el dueño o admin pueden ver
+	if !isAdmin && booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para ver esta reserva")
+	}
+
+	events, err := s.bookingRepo.FindEvents(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.BookingEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, mapBookingEvent(&event))
+	}
+	return response, nil
+}
+
+// recordEvent guarda en el historial lo que cambió entre before y after (before nil si se acaba de crear).
+// Se llama con el repositorio de la transacción que aplica el cambio para que ambos se guarden juntos.
+func recordEvent(repo *repositories.BookingRepository, before, after *models.Booking, actorID *uint, reason string) error {
+	event := models.NewBookingEvent(before, after, actorID, reason)
+	if event == nil {
+		return nil
+	}
+	return repo.CreateEvent(event)
+}
+
+// mapBookingEvent convierte un evento del historial a DTO
+func mapBookingEvent(event *models.BookingEvent) dto.BookingEventResponse {
+	changes := make([]dto.FieldChangeResponse, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		changes = append(changes, dto.FieldChangeResponse{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	response := dto.BookingEventResponse{
+		ID:        event.ID,
+		BookingID: event.BookingID,
+		Type:      string(event.Type),
+		ActorID:   event.ActorID,
+		Changes:   changes,
+		Reason:    event.Reason,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.Actor != nil {
+		response.ActorName = event.Actor.FullName
+	}
+	return response
+}
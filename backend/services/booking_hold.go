@@ -0,0 +1,148 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BookingHoldDuration es el tiempo que una retención bloquea el horario mientras se completa el checkout
+const BookingHoldDuration = 10 * time.Minute
+
+// Motivos registrados al cancelar automáticamente una reserva pendiente
+const (
+	reasonHoldExpired    = "la retención expiró sin completar la reserva"
+	reasonPendingExpired = "la reserva no se confirmó dentro del plazo"
+)
+
+// CreateHold retiene un horario durante BookingHoldDuration como reserva pendiente.
+// La retención cuenta para conflictos y cuotas igual que una reserva normal.
+func (s *BookingService) CreateHold(userID uint, req *dto.HoldBookingRequest) (*dto.BookingResponse, error) {
+	if !req.StartDatetime.Before(req.EndDatetime) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	if req.StartDatetime.Before(time.Now()) {
+		return nil, errors.New("no se pueden crear reservas en el pasado")
+	}
+
+	resource, err := s.resourceRepo.FindByID(req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !resource.IsActive {
+		return nil, errors.New("el recurso no está disponible")
+	}
+
+	expiresAt := time.Now().Add(BookingHoldDuration).UTC()
+	booking := &models.Booking{
+		UserID:        userID,
+		StartDatetime: req.StartDatetime,
+		EndDatetime:   req.EndDatetime,
+		Seats:         req.Seats,
+		Notes:         req.Notes,
+		IsHold:        true,
+		ExpiresAt:     &expiresAt,
+	}
+	if err := s.book(booking, resource, &userID, "", false); err != nil {
+		return nil, err
+	}
+
+	return s.GetBookingByID(booking.ID, userID, false)
+}
+
+// CompleteHold convierte una retención vigente en una reserva normal: confirmada si el recurso
+// se confirma automáticamente o pendiente de aprobación, caducando según el plazo del recurso.
+func (s *BookingService) CompleteHold(id uint, userID uint) (*dto.BookingResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para completar esta reserva")
+	}
+
+	if !booking.IsHold {
+		return nil, errors.New("la reserva no es una retención")
+	}
+
+	now := time.Now()
+	resource := &booking.Resource
+	var completed bool
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		var err error
+		completed, err = txRepo.CompleteHold(booking.ID, resource.InitialStatus(), resource.PendingExpiry(now), now)
+		if err != nil || !completed {
+			return err
+		}
+
+		after := *booking
+		after.Status = resource.InitialStatus()
+		return recordEvent(txRepo, booking, &after, &userID, "")
+	})
+	if err != nil {
+		return nil, errors.New("error al completar la reserva")
+	}
+	if !completed {
+		return nil, errors.New("la retención expiró o ya no está pendiente")
+	}
+
+	return s.GetBookingByID(booking.ID, userID, false)
+}
+
+// ExpirePendingBookings cancela las reservas pendientes cuyo plazo venció, registra el motivo,
+// avisa al titular y ofrece el horario a la lista de espera. Devuelve cuántas se cancelaron.
+func (s *BookingService) ExpirePendingBookings() (int, error) {
+	now := time.Now()
+	bookings, err := s.bookingRepo.FindExpiredPending(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range bookings {
+		booking := &bookings[i]
+
+		reason := reasonPendingExpired
+		if booking.IsHold {
+			reason = reasonHoldExpired
+		}
+
+		// Una confirmación simultánea gana: solo se cancela si sigue pendiente
+		var cancelled bool
+		err := s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+			var err error
+			cancelled, err = txRepo.ExpirePending(booking.ID, reason, now)
+			if err != nil || !cancelled {
+				return err
+			}
+
+			after := *booking
+			after.Status = models.StatusCancelled
+			return recordEvent(txRepo, booking, &after, nil, reason)
+		})
+		if err != nil {
+			return expired, err
+		}
+		if !cancelled {
+			continue
+		}
+		expired++
+
+		if !booking.IsHold {
+			_ = s.notificationRepo.Create(&models.Notification{
+				UserID:    booking.UserID,
+				BookingID: &booking.ID,
+				Message:   fmt.Sprintf("Tu reserva #%d fue cancelada: %s", booking.ID, reason),
+			})
+		}
+
+		s.promoteWaitlist(&booking.Resource, booking.StartDatetime, booking.EndDatetime)
+	}
+
+	return expired, nil
+}
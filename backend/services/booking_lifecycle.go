@@ -0,0 +1,320 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"Reservify/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultCheckInMinutes es la ventana de check-in por defecto de un recurso nuevo,
+// tanto antes del inicio como de gracia después
+const DefaultCheckInMinutes = 15
+
+// CompleteFinishedBookings cierra las reservas confirmadas que ya terminaron: las de recursos que
+// exigen check-in y no lo tuvieron pasan a no_show y el resto a completed. Devuelve cuántas cambiaron.
+func (s *BookingService) CompleteFinishedBookings() (int, error) {
+	bookings, err := s.bookingRepo.FindFinished(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	finished := 0
+	for i := range bookings {
+		booking := &bookings[i]
+
+		status, reason := models.StatusCompleted, ""
+		if booking.Resource.RequiresCheckIn && booking.CheckedInAt == nil {
+			status, reason = models.StatusNoShow, reasonNoCheckIn
+		}
+
+		// Una cancelación o un cambio manual simultáneo gana: solo se cierra si sigue confirmada
+		err := s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+			changed, err := txRepo.TransitionStatus(booking.ID, models.StatusConfirmed, status)
+			if err != nil || !changed {
+				return err
+			}
+			finished++
+
+			after := *booking
+			after.Status = status
+			return recordEvent(txRepo, booking, &after, nil, reason)
+		})
+		if err != nil {
+			return finished, err
+		}
+	}
+
+	return finished, nil
+}
+
+// ReleaseUnclaimedBookings libera las reservas en curso de recursos que exigen check-in cuando
+// terminó el periodo de gracia sin registrar la llegada: pasan a no_show, se avisa al titular y
+// el resto del horario se ofrece a la lista de espera. Devuelve cuántas se liberaron.
+func (s *BookingService) ReleaseUnclaimedBookings() (int, error) {
+	now := time.Now()
+	bookings, err := s.bookingRepo.FindUnclaimed(now)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for i := range bookings {
+		booking := &bookings[i]
+		if now.Before(booking.Resource.CheckInClosesAt(booking.StartDatetime, booking.EndDatetime)) {
+			continue
+		}
+
+		// Un check-in de último momento gana: solo se libera si sigue sin llegada registrada
+		var changed bool
+		err := s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+			var err error
+			changed, err = txRepo.ReleaseUnclaimed(booking.ID)
+			if err != nil || !changed {
+				return err
+			}
+
+			after := *booking
+			after.Status = models.StatusNoShow
+			return recordEvent(txRepo, booking, &after, nil, reasonNoCheckIn)
+		})
+		if err != nil {
+			return released, err
+		}
+		if !changed {
+			continue
+		}
+		released++
+
+		_ = s.notificationRepo.Create(&models.Notification{
+			UserID:    booking.UserID,
+			BookingID: &booking.ID,
+			Message:   fmt.Sprintf("Tu reserva #%d en %s se liberó porque no se registró la llegada", booking.ID, booking.Resource.Name),
+		})
+
+		s.promoteWaitlist(&booking.Resource, now, booking.EndDatetime)
+	}
+
+	return released, nil
+}
+
+// GetCheckInCode devuelve el código firmado de check-in de una reserva confirmada y el contenido
+// para mostrarlo como QR, junto con la ventana en la que se puede usar
+func (s *BookingService) GetCheckInCode(id uint, userID uint, isAdmin bool) (*dto.CheckInCodeResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos: solo el dueño o admin pueden ver
+	if !isAdmin && booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para ver esta reserva")
+	}
+
+	if booking.Status != models.StatusConfirmed {
+		return nil, errors.New("solo las reservas confirmadas tienen código de check-in")
+	}
+
+	token := utils.SignCheckInToken(booking.ID, booking.StartDatetime)
+	return &dto.CheckInCodeResponse{
+		BookingID:       booking.ID,
+		Token:           token,
+		QRPayload:       utils.CheckInURL(booking.ID, token),
+		OpensAt:         booking.Resource.CheckInOpensAt(booking.StartDatetime).UTC(),
+		ClosesAt:        booking.Resource.CheckInClosesAt(booking.StartDatetime, booking.EndDatetime).UTC(),
+		RequiresCheckIn: booking.Resource.RequiresCheckIn,
+		CheckedInAt:     booking.CheckedInAt,
+	}, nil
+}
+
+// CheckIn registra la llegada a una reserva confirmada dentro de la ventana del recurso.
+// Se necesita el código firmado de la reserva (el del QR); un admin puede registrarla sin él.
+func (s *BookingService) CheckIn(id uint, userID uint, isAdmin bool, token string) (*dto.BookingResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin {
+		if token == "" {
+			return nil, errors.New("escanea el código QR de la reserva para registrar la llegada")
+		}
+		if !utils.VerifyCheckInToken(booking.ID, booking.StartDatetime, token) {
+			return nil, errors.New("el código de check-in no es válido para esta reserva")
+		}
+	}
+
+	if booking.Status != models.StatusConfirmed {
+		return nil, errors.New("solo se puede registrar la llegada a reservas confirmadas")
+	}
+
+	if booking.CheckedInAt != nil {
+		return nil, errors.New("la llegada ya fue registrada")
+	}
+
+	now := time.Now()
+	if now.Before(booking.Resource.CheckInOpensAt(booking.StartDatetime)) {
+		return nil, errors.New("todavía no se puede registrar la llegada a esta reserva")
+	}
+	if !now.Before(booking.Resource.CheckInClosesAt(booking.StartDatetime, booking.EndDatetime)) {
+		return nil, errors.New("terminó el plazo para registrar la llegada")
+	}
+
+	before := *booking
+	checkedInAt := now.UTC()
+	booking.CheckedInAt = &checkedInAt
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.Update(booking); err != nil {
+			return err
+		}
+		return recordEvent(txRepo, &before, booking, &userID, "")
+	})
+	if err != nil {
+		return nil, errors.New("error al registrar la llegada")
+	}
+
+	return s.mapToResponse(booking), nil
+}
+
+// EndBooking termina ahora una reserva confirmada en curso: acorta el fin a este momento,
+// recalcula el precio por el tiempo usado y ofrece el resto del horario a la lista de espera
+func (s *BookingService) EndBooking(id uint, userID uint, isAdmin bool, req *dto.EndBookingRequest) (*dto.BookingResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkInProgress(booking, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if !now.After(booking.StartDatetime) {
+		return nil, errors.New("la reserva todavía no empezó")
+	}
+
+	resource := &booking.Resource
+	before := *booking
+	previousEnd := booking.EndDatetime
+	booking.EndDatetime = now
+	booking.TotalPrice = s.calculatePrice(resource, booking.Seats, booking.StartDatetime, now)
+
+	// Solo se acorta si sigue confirmada y sin terminar: el cierre automático puede adelantarse
+	var ended bool
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		var err error
+		ended, err = txRepo.ChangeEnd(booking.ID, previousEnd, now, booking.TotalPrice)
+		if err != nil || !ended {
+			return err
+		}
+		return recordEvent(txRepo, &before, booking, &userID, strings.TrimSpace(req.Reason))
+	})
+	if err != nil {
+		return nil, errors.New("error al terminar la reserva")
+	}
+	if !ended {
+		return nil, errBookingNotInProgress
+	}
+
+	s.promoteWaitlist(resource, now, previousEnd)
+
+	return s.GetBookingByID(booking.ID, userID, isAdmin)
+}
+
+// ExtendBooking amplía el fin de una reserva confirmada en curso. Solo se valida el tramo añadido:
+// que esté dentro de los horarios de atención y libre, además de la duración máxima y las cuotas.
+func (s *BookingService) ExtendBooking(id uint, userID uint, isAdmin bool, req *dto.ExtendBookingRequest) (*dto.BookingResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkInProgress(booking, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	if !time.Now().After(booking.StartDatetime) {
+		return nil, errors.New("la reserva todavía no empezó; edítala en lugar de ampliarla")
+	}
+
+	end := req.EndDatetime.UTC()
+	if !end.After(booking.EndDatetime) {
+		return nil, errors.New("la nueva hora de fin debe ser posterior a la actual")
+	}
+
+	resource := &booking.Resource
+	policy, err := loadPolicy(s.policyRepo, resource)
+	if err != nil {
+		return nil, err
+	}
+	// La antelación y la granularidad del inicio no aplican a una reserva que ya empezó
+	if err := checkDurationPolicy(policy, booking.StartDatetime, end); err != nil {
+		return nil, err
+	}
+
+	// Verificar las cuotas del titular sin contar la propia reserva
+	if err := s.validateQuota(booking.UserID, resource, booking.StartDatetime, end, []uint{id}); err != nil {
+		return nil, err
+	}
+
+	previousEnd := booking.EndDatetime
+	if err := s.validateOpeningHours(resource, previousEnd, end); err != nil {
+		return nil, err
+	}
+
+	before := *booking
+	booking.EndDatetime = end
+	booking.TotalPrice = s.calculatePrice(resource, booking.Seats, booking.StartDatetime, end)
+
+	// Verificar solapamiento del tramo añadido (excluyendo esta reserva) y guardar de forma atómica
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.LockResource(booking.ResourceID); err != nil {
+			return err
+		}
+
+		if err := checkConflict(txRepo, resource, previousEnd, end, booking.Seats, []uint{id}); err != nil {
+			return err
+		}
+
+		extended, err := txRepo.ChangeEnd(booking.ID, previousEnd, end, booking.TotalPrice)
+		if err != nil {
+			return err
+		}
+		if !extended {
+			return errBookingNotInProgress
+		}
+		return recordEvent(txRepo, &before, booking, &userID, strings.TrimSpace(req.Reason))
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) || errors.Is(err, errBookingNotInProgress) {
+			return nil, err
+		}
+		return nil, errors.New("error al ampliar la reserva")
+	}
+
+	return s.GetBookingByID(booking.ID, userID, isAdmin)
+}
+
+// errBookingNotInProgress indica que la reserva cambió de estado o de horario mientras se ampliaba
+var errBookingNotInProgress = errors.New("la reserva ya no está en curso")
+
+// checkInProgress verifica que el usuario pueda gestionar la reserva y que siga confirmada y sin terminar
+func checkInProgress(booking *models.Booking, userID uint, isAdmin bool) error {
+	if !isAdmin && booking.UserID != userID {
+		return errors.New("no tienes permisos para modificar esta reserva")
+	}
+
+	// Las reservas de una reserva múltiple se gestionan en conjunto
+	if !isAdmin && booking.ReservationID != nil {
+		return ErrReservationItem
+	}
+
+	if booking.Status != models.StatusConfirmed || !time.Now().Before(booking.EndDatetime) {
+		return errBookingNotInProgress
+	}
+	return nil
+}
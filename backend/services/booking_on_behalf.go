@@ -0,0 +1,118 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreateBookingForUser crea una reserva en nombre de otro usuario (por ejemplo, una reserva telefónica).
+// Con override se omiten la antelación, el horizonte, las cuotas y la restricción de horarios pasados;
+// la disponibilidad, los horarios de atención y la capacidad se validan siempre.
+// La creación queda en el historial a nombre del admin.
+func (s *BookingService) CreateBookingForUser(adminID uint, req *dto.AdminCreateBookingRequest) (*dto.BookingResponse, error) {
+	reason, err := overrideReason(req.Override, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StartDatetime.After(req.EndDatetime) || req.StartDatetime.Equal(req.EndDatetime) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	if !req.Override && req.StartDatetime.Before(time.Now()) {
+		return nil, errors.New("no se pueden crear reservas en el pasado")
+	}
+
+	if _, err := s.userRepo.FindByID(req.UserID); err != nil {
+		return nil, err
+	}
+
+	resource, err := s.resourceRepo.FindByID(req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !resource.IsActive {
+		return nil, errors.New("el recurso no está disponible")
+	}
+
+	booking := &models.Booking{
+		UserID:        req.UserID,
+		StartDatetime: req.StartDatetime,
+		EndDatetime:   req.EndDatetime,
+		Seats:         req.Seats,
+		Notes:         req.Notes,
+	}
+	if err := s.book(booking, resource, &adminID, reason, req.Override); err != nil {
+		return nil, err
+	}
+
+	s.notifyOwner(booking, adminID, fmt.Sprintf("Se creó la reserva #%d en %s a tu nombre", booking.ID, resource.Name))
+
+	return s.GetBookingByID(booking.ID, adminID, true)
+}
+
+// UpdateBookingForUser modifica una reserva pendiente o confirmada en nombre de su titular.
+// override omite las mismas políticas que al crearla; el cambio queda en el historial a nombre del admin.
+func (s *BookingService) UpdateBookingForUser(id uint, adminID uint, req *dto.AdminUpdateBookingRequest) (*dto.BookingResponse, error) {
+	reason, err := overrideReason(req.Override, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
+		return nil, errors.New("solo se pueden editar reservas pendientes o confirmadas")
+	}
+
+	update := req.UpdateBookingRequest
+	update.Reason = reason
+	if err := s.reschedule(booking, &update, adminID, req.Override); err != nil {
+		return nil, err
+	}
+
+	s.notifyOwner(booking, adminID, fmt.Sprintf("Se modificó tu reserva #%d en %s", booking.ID, booking.Resource.Name))
+
+	return s.GetBookingByID(booking.ID, adminID, true)
+}
+
+// CancelBookingForUser cancela una reserva en nombre de su titular y le avisa
+func (s *BookingService) CancelBookingForUser(id uint, adminID uint, waiveFee bool, reason string) (*dto.BookingResponse, error) {
+	response, err := s.CancelBooking(id, adminID, true, waiveFee, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	booking := &models.Booking{ID: response.ID, UserID: response.UserID}
+	s.notifyOwner(booking, adminID, fmt.Sprintf("Se canceló tu reserva #%d en %s", response.ID, response.Resource.Name))
+
+	return response, nil
+}
+
+// notifyOwner avisa al titular de un cambio en su reserva si no lo hizo él mismo
+func (s *BookingService) notifyOwner(booking *models.Booking, actorID uint, message string) {
+	if booking.UserID == actorID {
+		return
+	}
+	_ = s.notificationRepo.Create(&models.Notification{
+		UserID:    booking.UserID,
+		BookingID: &booking.ID,
+		Message:   message,
+	})
+}
+
+// overrideReason exige un motivo cuando se omiten políticas, para que quede constancia en el historial
+func overrideReason(override bool, reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if override && reason == "" {
+		return "", errors.New("indica el motivo para omitir las políticas de reserva")
+	}
+	return reason, nil
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Reglas de la política de reservas, usadas para identificar qué regla se incumple
+const (
+	PolicyRuleMinDuration      = "min_duration"
+	PolicyRuleMaxDuration      = "max_duration"
+	PolicyRuleMinNotice        = "min_notice"
+	PolicyRuleMaxDaysAhead     = "max_days_ahead"
+	PolicyRuleStartGranularity = "start_granularity"
+	PolicyRuleCancelDeadline   = "cancel_deadline"
+)
+
+// PolicyViolation indica que una reserva incumple una regla concreta de la política del recurso
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Limit   int    `json:"limit"` // Valor configurado (minutos, o días para max_days_ahead)
+	Message string `json:"message"`
+}
+
+func (e *PolicyViolation) Error() string {
+	return e.Message
+}
+
+type BookingPolicyService struct {
+	policyRepo   *repositories.BookingPolicyRepository
+	resourceRepo *repositories.ResourceRepository
+}
+
+func NewBookingPolicyService(policyRepo *repositories.BookingPolicyRepository, resourceRepo *repositories.ResourceRepository) *BookingPolicyService {
+	return &BookingPolicyService{
+		policyRepo:   policyRepo,
+		resourceRepo: resourceRepo,
+	}
+}
+
+// GetAllPolicies obtiene todas las políticas configuradas
+func (s *BookingPolicyService) GetAllPolicies() ([]dto.BookingPolicyResponse, error) {
+	policies, err := s.policyRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.BookingPolicyResponse, 0, len(policies))
+	for i := range policies {
+		response = append(response, *mapPolicyResponse(&policies[i]))
+	}
+	return response, nil
+}
+
+// GetEffectivePolicy obtiene la política que se aplica a un recurso, con la herencia resuelta
+func (s *BookingPolicyService) GetEffectivePolicy(resourceID uint) (*dto.BookingPolicyResponse, error) {
+	resource, err := s.resourceRepo.FindByID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := loadPolicy(s.policyRepo, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapPolicyResponse(&policy)
+	response.ID = 0
+	response.ResourceID = &resource.ID
+	response.Category = resource.Category
+	response.Scope = "effective"
+	return response, nil
+}
+
+// SavePolicy crea o reemplaza la política de un recurso, de una categoría o la global
+func (s *BookingPolicyService) SavePolicy(req *dto.BookingPolicyRequest) (*dto.BookingPolicyResponse, error) {
+	if req.ResourceID != nil && req.Category != "" {
+		return nil, errors.New("indica un recurso o una categoría, no ambos")
+	}
+	if req.ResourceID != nil {
+		if _, err := s.resourceRepo.FindByID(*req.ResourceID); err != nil {
+			return nil, err
+		}
+	}
+	if req.MinDurationMinutes != nil && req.MaxDurationMinutes != nil && *req.MinDurationMinutes > *req.MaxDurationMinutes {
+		return nil, errors.New("la duración mínima no puede superar la máxima")
+	}
+
+	policy, err := s.policyRepo.FindByScope(req.ResourceID, req.Category)
+	if err != nil {
+		policy = &models.BookingPolicy{ResourceID: req.ResourceID, Category: req.Category}
+	}
+
+	policy.MinDurationMinutes = req.MinDurationMinutes
+	policy.MaxDurationMinutes = req.MaxDurationMinutes
+	policy.MinNoticeMinutes = req.MinNoticeMinutes
+	policy.MaxDaysAhead = req.MaxDaysAhead
+	policy.StartGranularityMinutes = req.StartGranularityMinutes
+	policy.CancelDeadlineMinutes = req.CancelDeadlineMinutes
+	policy.CancellationFees = buildFeeTiers(req.CancellationFees)
+
+	if err := s.policyRepo.Save(policy); err != nil {
+		return nil, errors.New("error al guardar la política")
+	}
+
+	return mapPolicyResponse(policy), nil
+}
+
+// DeletePolicy elimina una política; el nivel afectado vuelve a heredar del más general
+func (s *BookingPolicyService) DeletePolicy(id uint) error {
+	return s.policyRepo.Delete(id)
+}
+
+// loadPolicy obtiene la política efectiva de un recurso (recurso → categoría → global)
+func loadPolicy(policyRepo *repositories.BookingPolicyRepository, resource *models.Resource) (models.BookingPolicy, error) {
+	policies, err := policyRepo.FindApplicable(resource)
+	if err != nil {
+		return models.BookingPolicy{}, err
+	}
+	return resolvePolicy(policies), nil
+}
+
+// resolvePolicy combina las políticas aplicables empezando por la más específica
+func resolvePolicy(policies []models.BookingPolicy) models.BookingPolicy {
+	rank := map[string]int{"resource": 0, "category": 1, "global": 2}
+	sort.Slice(policies, func(i, j int) bool {
+		return rank[policies[i].Scope()] < rank[policies[j].Scope()]
+	})
+
+	var effective models.BookingPolicy
+	for _, policy := range policies {
+		effective = effective.Inherit(policy)
+	}
+	return effective
+}
+
+// checkBookingPolicy valida duración, antelación, horizonte y granularidad de una reserva
+func checkBookingPolicy(policy models.BookingPolicy, start, end, now time.Time, loc *time.Location) error {
+	if err := checkDurationPolicy(policy, start, end); err != nil {
+		return err
+	}
+	if limit := policy.MinNoticeMinutes; limit != nil && start.Before(now.Add(time.Duration(*limit)*time.Minute)) {
+		return &PolicyViolation{Rule: PolicyRuleMinNotice, Limit: *limit,
+			Message: fmt.Sprintf("la reserva debe hacerse con al menos %d minutos de antelación", *limit)}
+	}
+	if limit := policy.MaxDaysAhead; limit != nil && start.After(now.AddDate(0, 0, *limit)) {
+		return &PolicyViolation{Rule: PolicyRuleMaxDaysAhead, Limit: *limit,
+			Message: fmt.Sprintf("no se puede reservar con más de %d días de antelación", *limit)}
+	}
+	return checkGranularityPolicy(policy, start, loc)
+}
+
+// checkGranularityPolicy valida que la reserva empiece en un múltiplo de la granularidad de la política
+func checkGranularityPolicy(policy models.BookingPolicy, start time.Time, loc *time.Location) error {
+	if limit := policy.StartGranularityMinutes; limit != nil && *limit > 0 {
+		// La granularidad se mide desde la medianoche local del recurso
+		local := start.In(loc)
+		midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		if local.Sub(midnight)%(time.Duration(*limit)*time.Minute) != 0 {
+			return &PolicyViolation{Rule: PolicyRuleStartGranularity, Limit: *limit,
+				Message: fmt.Sprintf("la reserva debe empezar en múltiplos de %d minutos", *limit)}
+		}
+	}
+	return nil
+}
+
+// checkDurationPolicy valida solo la duración mínima y máxima; se usa también al ampliar
+// una reserva en curso, donde la antelación y la granularidad del inicio ya no aplican
+func checkDurationPolicy(policy models.BookingPolicy, start, end time.Time) error {
+	duration := end.Sub(start)
+
+	if limit := policy.MinDurationMinutes; limit != nil && duration < time.Duration(*limit)*time.Minute {
+		return &PolicyViolation{Rule: PolicyRuleMinDuration, Limit: *limit,
+			Message: fmt.Sprintf("la reserva debe durar al menos %d minutos", *limit)}
+	}
+	if limit := policy.MaxDurationMinutes; limit != nil && duration > time.Duration(*limit)*time.Minute {
+		return &PolicyViolation{Rule: PolicyRuleMaxDuration, Limit: *limit,
+			Message: fmt.Sprintf("la reserva no puede durar más de %d minutos", *limit)}
+	}
+	return nil
+}
+
+// checkCancellationPolicy valida que todavía se pueda cancelar una reserva que empieza en start
+func checkCancellationPolicy(policy models.BookingPolicy, start, now time.Time) error {
+	if limit := policy.CancelDeadlineMinutes; limit != nil && now.After(start.Add(-time.Duration(*limit)*time.Minute)) {
+		return &PolicyViolation{Rule: PolicyRuleCancelDeadline, Limit: *limit,
+			Message: fmt.Sprintf("solo se puede cancelar hasta %d minutos antes del inicio", *limit)}
+	}
+	return nil
+}
+
+// cancellationCharge calcula el porcentaje, el cargo y el reembolso de cancelar en now una reserva
+// que empieza en start y cuesta totalPrice
+func cancellationCharge(policy models.BookingPolicy, totalPrice float64, start, now time.Time) (percent, fee, refund float64) {
+	percent = policy.CancellationFees.FeePercent(start.Sub(now))
+	fee = math.Round(totalPrice*percent) / 100
+	return percent, fee, totalPrice - fee
+}
+
+// buildFeeTiers convierte los tramos del DTO conservando nil (heredar) frente a vacío (gratis)
+func buildFeeTiers(tiers []dto.CancellationFeeTier) models.FeeTiers {
+	if tiers == nil {
+		return nil
+	}
+	result := make(models.FeeTiers, 0, len(tiers))
+	for _, tier := range tiers {
+		result = append(result, models.FeeTier{MinutesBefore: tier.MinutesBefore, FeePercent: tier.FeePercent})
+	}
+	// De la antelación mayor a la menor, como se leen habitualmente
+	sort.Slice(result, func(i, j int) bool { return result[i].MinutesBefore > result[j].MinutesBefore })
+	return result
+}
+
+func mapPolicyResponse(policy *models.BookingPolicy) *dto.BookingPolicyResponse {
+	var fees []dto.CancellationFeeTier
+	if policy.CancellationFees != nil {
+		fees = make([]dto.CancellationFeeTier, 0, len(policy.CancellationFees))
+		for _, tier := range policy.CancellationFees {
+			fees = append(fees, dto.CancellationFeeTier{MinutesBefore: tier.MinutesBefore, FeePercent: tier.FeePercent})
+		}
+	}
+
+	return &dto.BookingPolicyResponse{
+		ID:                      policy.ID,
+		Scope:                   policy.Scope(),
+		ResourceID:              policy.ResourceID,
+		Category:                policy.Category,
+		MinDurationMinutes:      policy.MinDurationMinutes,
+		MaxDurationMinutes:      policy.MaxDurationMinutes,
+		MinNoticeMinutes:        policy.MinNoticeMinutes,
+		MaxDaysAhead:            policy.MaxDaysAhead,
+		StartGranularityMinutes: policy.StartGranularityMinutes,
+		CancelDeadlineMinutes:   policy.CancelDeadlineMinutes,
+		CancellationFees:        fees,
+	}
+}
+
+// cancelWithCharge cancela la reserva guardando el cargo y el reembolso de la política; con waiveFee se reembolsa todo
+func cancelWithCharge(booking *models.Booking, policy models.BookingPolicy, waiveFee bool, now time.Time) {
+	booking.Status = models.StatusCancelled
+	booking.CancelFee = 0
+	booking.RefundAmount = booking.TotalPrice
+	if !waiveFee {
+		_, booking.CancelFee, booking.RefundAmount = cancellationCharge(policy, booking.TotalPrice, booking.StartDatetime, now)
+	}
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrReservationItem indica que una reserva individual forma parte de una reserva múltiple
+var ErrReservationItem = errors.New("esta reserva forma parte de una reserva múltiple; modifícala o cancélala desde la reserva múltiple")
+
+// reservationItem es un elemento validado de una reserva múltiple, listo para guardarse
+type reservationItem struct {
+	resource *models.Resource
+	booking  *models.Booking
+}
+
+// GetMyReservations obtiene las reservas múltiples del usuario
+func (s *BookingService) GetMyReservations(userID uint) ([]dto.ReservationResponse, error) {
+	reservations, err := s.bookingRepo.FindReservationsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ReservationResponse, 0, len(reservations))
+	for i := range reservations {
+		response = append(response, *s.mapReservation(&reservations[i]))
+	}
+	return response, nil
+}
+
+// GetReservation obtiene una reserva múltiple con sus reservas y el precio combinado
+func (s *BookingService) GetReservation(id uint, userID uint, isAdmin bool) (*dto.ReservationResponse, error) {
+	reservation, err := s.bookingRepo.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos: solo el dueño o admin pueden ver
+	if !isAdmin && reservation.UserID != userID {
+		return nil, errors.New("no tienes permisos para ver esta reserva")
+	}
+
+	return s.mapReservation(reservation), nil
+}
+
+// CreateReservation reserva varios recursos en una sola transacción: se crean todas o ninguna
+func (s *BookingService) CreateReservation(userID uint, req *dto.ReservationRequest) (*dto.ReservationResponse, error) {
+	items, err := s.buildReservationItems(userID, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := &models.Reservation{
+		UserID: userID,
+		Title:  req.Title,
+		Notes:  req.Notes,
+	}
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := lockResources(txRepo, itemResourceIDs(items, nil)); err != nil {
+			return err
+		}
+
+		if err := txRepo.CreateReservation(reservation); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			booking := item.booking
+			if err := checkConflict(txRepo, item.resource, booking.StartDatetime, booking.EndDatetime, booking.Seats, nil); err != nil {
+				return fmt.Errorf("%s: %w", item.resource.Name, err)
+			}
+
+			booking.ReservationID = &reservation.ID
+			if err := txRepo.Create(booking); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, nil, booking, &userID, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return nil, err
+		}
+		return nil, errors.New("error al crear la reserva múltiple")
+	}
+
+	return s.GetReservation(reservation.ID, userID, false)
+}
+
+// UpdateReservation reemplaza los recursos y horarios de una reserva múltiple de forma atómica.
+// Los recursos que se mantienen conservan su reserva, los nuevos se crean y los que faltan se cancelan.
+func (s *BookingService) UpdateReservation(id uint, userID uint, req *dto.ReservationRequest, isAdmin bool) (*dto.ReservationResponse, error) {
+	reservation, err := s.bookingRepo.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos
+	if !isAdmin && reservation.UserID != userID {
+		return nil, errors.New("no tienes permisos para editar esta reserva")
+	}
+
+	// Igual que una reserva individual, solo se edita mientras todo sigue pendiente
+	existing := make(map[uint]*models.Booking)
+	previous := make(map[uint]models.Booking)
+	var excludeIDs []uint
+	for i := range reservation.Bookings {
+		booking := &reservation.Bookings[i]
+		switch booking.Status {
+		case models.StatusPending:
+			existing[booking.ResourceID] = booking
+			previous[booking.ResourceID] = *booking
+			excludeIDs = append(excludeIDs, booking.ID)
+		case models.StatusConfirmed:
+			return nil, errors.New("solo se pueden editar reservas múltiples con todas sus reservas pendientes")
+		}
+	}
+	if len(existing) == 0 {
+		return nil, errors.New("la reserva múltiple no tiene reservas pendientes para editar")
+	}
+
+	items, err := s.buildReservationItems(reservation.UserID, req, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Los recursos que ya no están en la solicitud se cancelan con las reglas habituales
+	kept := make(map[uint]bool, len(items))
+	for _, item := range items {
+		kept[item.resource.ID] = true
+	}
+	now := time.Now()
+	var removed []*models.Booking
+	for resourceID, booking := range existing {
+		if kept[resourceID] {
+			continue
+		}
+		policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			if err := checkCancellationPolicy(policy, booking.StartDatetime, now); err != nil {
+				return nil, fmt.Errorf("%s: %w", booking.Resource.Name, err)
+			}
+		}
+		cancelWithCharge(booking, policy, false, now)
+		removed = append(removed, booking)
+	}
+
+	reservation.Title = req.Title
+	reservation.Notes = req.Notes
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := lockResources(txRepo, itemResourceIDs(items, removed)); err != nil {
+			return err
+		}
+
+		if err := txRepo.UpdateReservation(reservation); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			booking := item.booking
+			if err := checkConflict(txRepo, item.resource, booking.StartDatetime, booking.EndDatetime, booking.Seats, excludeIDs); err != nil {
+				return fmt.Errorf("%s: %w", item.resource.Name, err)
+			}
+
+			var before *models.Booking
+			if booking.ID == 0 {
+				booking.ReservationID = &reservation.ID
+				err = txRepo.Create(booking)
+			} else {
+				previousBooking := previous[booking.ResourceID]
+				before = &previousBooking
+				err = txRepo.Update(booking)
+			}
+			if err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, before, booking, &userID, ""); err != nil {
+				return err
+			}
+		}
+
+		for _, booking := range removed {
+			previousBooking := previous[booking.ResourceID]
+			if err := txRepo.Update(booking); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, &previousBooking, booking, &userID, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return nil, err
+		}
+		return nil, errors.New("error al actualizar la reserva múltiple")
+	}
+
+	// Los horarios anteriores pueden haber quedado libres para la lista de espera
+	for _, booking := range previous {
+		s.promoteWaitlist(&booking.Resource, booking.StartDatetime, booking.EndDatetime)
+	}
+
+	return s.GetReservation(reservation.ID, userID, isAdmin)
+}
+
+// CancelReservation cancela todas las reservas activas de una reserva múltiple.
+// Si alguna ya no se puede cancelar no se cancela ninguna.
+func (s *BookingService) CancelReservation(id uint, userID uint, isAdmin bool, waiveFee bool) (*dto.ReservationResponse, error) {
+	reservation, err := s.bookingRepo.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos
+	if !isAdmin && reservation.UserID != userID {
+		return nil, errors.New("no tienes permisos para cancelar esta reserva")
+	}
+
+	now := time.Now()
+	var active []*models.Booking
+	previous := make(map[uint]models.Booking)
+	for i := range reservation.Bookings {
+		booking := &reservation.Bookings[i]
+		if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
+			continue
+		}
+
+		policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			if err := checkCancellationPolicy(policy, booking.StartDatetime, now); err != nil {
+				return nil, fmt.Errorf("%s: %w", booking.Resource.Name, err)
+			}
+		}
+
+		previous[booking.ID] = *booking
+		cancelWithCharge(booking, policy, isAdmin && waiveFee, now)
+		active = append(active, booking)
+	}
+	if len(active) == 0 {
+		return nil, errors.New("no hay reservas activas para cancelar en la reserva múltiple")
+	}
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		for _, booking := range active {
+			before := previous[booking.ID]
+			if err := txRepo.Update(booking); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, &before, booking, &userID, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("error al cancelar la reserva múltiple")
+	}
+
+	for _, booking := range active {
+		s.promoteWaitlist(&booking.Resource, booking.StartDatetime, booking.EndDatetime)
+	}
+
+	return s.mapReservation(reservation), nil
+}
+
+// buildReservationItems valida cada elemento (horario, recurso, plazas, política, horarios de atención
+// y cuotas) antes de abrir la transacción. existing son las reservas pendientes por recurso que se
+// reutilizan al editar; las cuotas se evalúan sin contarlas.
+func (s *BookingService) buildReservationItems(userID uint, req *dto.ReservationRequest, existing map[uint]*models.Booking) ([]reservationItem, error) {
+	excludeIDs := make([]uint, 0, len(existing))
+	for _, booking := range existing {
+		excludeIDs = append(excludeIDs, booking.ID)
+	}
+
+	// Los elementos se suman entre sí al evaluar las cuotas
+	quota, err := s.quotaTracker(userID, excludeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	seen := make(map[uint]bool, len(req.Items))
+	items := make([]reservationItem, 0, len(req.Items))
+	for _, itemReq := range req.Items {
+		if seen[itemReq.ResourceID] {
+			return nil, fmt.Errorf("el recurso %d aparece más de una vez", itemReq.ResourceID)
+		}
+		seen[itemReq.ResourceID] = true
+
+		start, end := req.StartDatetime, req.EndDatetime
+		if itemReq.StartDatetime != nil {
+			start = itemReq.StartDatetime
+		}
+		if itemReq.EndDatetime != nil {
+			end = itemReq.EndDatetime
+		}
+		if start == nil || end == nil {
+			return nil, fmt.Errorf("indica el horario del recurso %d o un horario general", itemReq.ResourceID)
+		}
+		if !start.Before(*end) {
+			return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+		}
+		if start.Before(now) {
+			return nil, errors.New("no se pueden crear reservas en el pasado")
+		}
+
+		resource, err := s.resourceRepo.FindByID(itemReq.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		if !resource.IsActive {
+			return nil, fmt.Errorf("%s: el recurso no está disponible", resource.Name)
+		}
+
+		seats, err := resolveSeats(resource, itemReq.Seats)
+		if err == nil {
+			err = s.validatePolicy(resource, *start, *end, false)
+		}
+		if err == nil {
+			err = s.validateOpeningHours(resource, *start, *end)
+		}
+		if err == nil {
+			err = quota.admit(models.Booking{StartDatetime: *start, EndDatetime: *end, Status: models.StatusPending}, resource)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", resource.Name, err)
+		}
+
+		booking, ok := existing[resource.ID]
+		if !ok {
+			booking = &models.Booking{
+				UserID:     userID,
+				ResourceID: resource.ID,
+				Status:     resource.InitialStatus(),
+				ExpiresAt:  resource.PendingExpiry(now),
+			}
+		}
+		booking.StartDatetime = start.UTC()
+		booking.EndDatetime = end.UTC()
+		booking.Seats = seats
+		booking.TotalPrice = s.calculatePrice(resource, seats, *start, *end)
+		booking.Notes = req.Notes
+
+		items = append(items, reservationItem{resource: resource, booking: booking})
+	}
+
+	return items, nil
+}
+
+// lockResources bloquea los recursos en orden de ID para que dos reservas múltiples no se bloqueen mutuamente
+func lockResources(txRepo *repositories.BookingRepository, resourceIDs []uint) error {
+	sort.Slice(resourceIDs, func(i, j int) bool { return resourceIDs[i] < resourceIDs[j] })
+	for _, id := range resourceIDs {
+		if err := txRepo.LockResource(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// itemResourceIDs devuelve los recursos distintos de los elementos y de las reservas que se cancelan
+func itemResourceIDs(items []reservationItem, removed []*models.Booking) []uint {
+	seen := make(map[uint]bool)
+	var ids []uint
+	add := func(id uint) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, item := range items {
+		add(item.resource.ID)
+	}
+	for _, booking := range removed {
+		add(booking.ResourceID)
+	}
+	return ids
+}
+
+// mapReservation convierte una reserva múltiple a DTO con el horario que abarcan sus reservas activas
+func (s *BookingService) mapReservation(reservation *models.Reservation) *dto.ReservationResponse {
+	response := &dto.ReservationResponse{
+		ID:         reservation.ID,
+		UserID:     reservation.UserID,
+		Title:      reservation.Title,
+		Notes:      reservation.Notes,
+		Status:     string(reservation.Status()),
+		TotalPrice: reservation.TotalPrice(),
+		Bookings:   s.mapToListResponse(reservation.Bookings),
+		CreatedAt:  reservation.CreatedAt,
+		UpdatedAt:  reservation.UpdatedAt,
+	}
+
+	cancelled := reservation.Status() == models.StatusCancelled
+	for _, booking := range reservation.Bookings {
+		if booking.Status == models.StatusCancelled && !cancelled {
+			continue
+		}
+		if response.StartDatetime.IsZero() || booking.StartDatetime.Before(response.StartDatetime) {
+			response.StartDatetime = booking.StartDatetime.UTC()
+		}
+		if booking.EndDatetime.After(response.EndDatetime) {
+			response.EndDatetime = booking.EndDatetime.UTC()
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,442 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"Reservify/utils"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Alcances para editar o cancelar reservas recurrentes
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+	SeriesScopeAll       = "all"
+)
+
+// Modos de manejo de conflictos al crear una serie
+const (
+	ConflictModeAllOrNothing  = "all_or_nothing"
+	ConflictModeSkipConflicts = "skip_conflicts"
+)
+
+// CreateRecurringBooking crea una serie de reservas a partir de una regla de recurrencia
+func (s *BookingService) CreateRecurringBooking(userID uint, req *dto.CreateBookingRequest) (*dto.BookingSeriesResponse, error) {
+	if req.Recurrence == nil {
+		return nil, errors.New("la reserva no tiene regla de recurrencia")
+	}
+
+	// Validar que start_datetime < end_datetime
+	if !req.StartDatetime.Before(req.EndDatetime) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	// Validar que la primera ocurrencia sea en el futuro
+	if req.StartDatetime.Before(time.Now()) {
+		return nil, errors.New("no se pueden crear reservas en el pasado")
+	}
+
+	// Verificar que el recurso existe y está activo
+	resource, err := s.resourceRepo.FindByID(req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !resource.IsActive {
+		return nil, errors.New("el recurso no está disponible")
+	}
+
+	rule, err := buildRecurrenceRule(req.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	// La regla se expande en hora local del recurso para que las 10:00 sigan siendo las 10:00 tras un cambio de horario
+	loc := resource.Location()
+	starts, err := utils.ExpandRecurrence(req.StartDatetime.In(loc), rule)
+	if err != nil {
+		return nil, err
+	}
+	if len(starts) == 0 {
+		return nil, errors.New("la recurrencia no genera ninguna ocurrencia")
+	}
+
+	// Calendario completo de la serie (horarios y cierres) cargado una sola vez
+	duration := req.EndDatetime.Sub(req.StartDatetime)
+	schedule, err := loadSchedule(s.availabilityRepo, s.blackoutRepo, resource, starts[0], starts[len(starts)-1].Add(duration))
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := loadPolicy(s.policyRepo, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := resolveSeats(resource, req.Seats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las ocurrencias se suman entre sí al evaluar las cuotas
+	quota, err := s.quotaTracker(userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	skipConflicts := req.ConflictMode == ConflictModeSkipConflicts
+	skipped := []dto.SkippedOccurrence{}
+
+	// Validar política y horarios de atención de cada ocurrencia antes de abrir la transacción
+	now := time.Now()
+	var candidates []*models.Booking
+	for _, start := range starts {
+		start = start.UTC()
+		end := start.Add(duration)
+		candidate := &models.Booking{
+			UserID:        userID,
+			ResourceID:    resource.ID,
+			StartDatetime: start,
+			EndDatetime:   end,
+			Status:        resource.InitialStatus(),
+			Seats:         seats,
+			TotalPrice:    s.calculatePrice(resource, seats, start, end),
+			Notes:         req.Notes,
+			ExpiresAt:     resource.PendingExpiry(now),
+		}
+
+		err := checkBookingPolicy(policy, start, end, now, loc)
+		if err == nil {
+			err = schedule.check(start, end)
+		}
+		if err == nil {
+			err = quota.admit(*candidate, resource)
+		}
+		if err != nil {
+			if !skipConflicts {
+				return nil, fmt.Errorf("ocurrencia del %s: %w", start.Format("2006-01-02 15:04"), err)
+			}
+			skipped = append(skipped, dto.SkippedOccurrence{StartDatetime: start, EndDatetime: end, Reason: err.Error()})
+			continue
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.New("ninguna ocurrencia de la serie está dentro del horario de atención")
+	}
+
+	series := &models.BookingSeries{
+		UserID:     userID,
+		ResourceID: resource.ID,
+		Frequency:  rule.Frequency,
+		Interval:   rule.Interval,
+		ByDay:      utils.FormatWeekdays(rule.ByDay),
+		Count:      rule.Count,
+		Until:      rule.Until,
+	}
+
+	var created []models.Booking
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.LockResource(resource.ID); err != nil {
+			return err
+		}
+
+		if err := txRepo.CreateSeries(series); err != nil {
+			return err
+		}
+
+		for _, booking := range candidates {
+			if err := checkConflict(txRepo, resource, booking.StartDatetime, booking.EndDatetime, booking.Seats, nil); err != nil {
+				if !errors.Is(err, ErrBookingConflict) {
+					return err
+				}
+				if !skipConflicts {
+					return fmt.Errorf("%w (ocurrencia del %s)", err, booking.StartDatetime.Format("2006-01-02 15:04"))
+				}
+				skipped = append(skipped, dto.SkippedOccurrence{
+					StartDatetime: booking.StartDatetime,
+					EndDatetime:   booking.EndDatetime,
+					Reason:        err.Error(),
+				})
+				continue
+			}
+
+			booking.SeriesID = &series.ID
+			if err := txRepo.Create(booking); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, nil, booking, &userID, ""); err != nil {
+				return err
+			}
+			created = append(created, *booking)
+		}
+
+		if len(created) == 0 {
+			return fmt.Errorf("%w (ninguna ocurrencia de la serie está libre)", ErrBookingConflict)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return nil, err
+		}
+		return nil, errors.New("error al crear la serie de reservas")
+	}
+
+	// Completar el recurso para la respuesta sin volver a consultarlo
+	for i := range created {
+		created[i].Resource = *resource
+	}
+
+	return &dto.BookingSeriesResponse{
+		SeriesID: series.ID,
+		Bookings: s.mapToListResponse(created),
+		Skipped:  skipped,
+	}, nil
+}
+
+// UpdateBookingSeries mueve esta y las siguientes ocurrencias, o toda la serie, aplicando el mismo desplazamiento
+func (s *BookingService) UpdateBookingSeries(id uint, userID uint, req *dto.UpdateBookingRequest, scope string, isAdmin bool) ([]dto.BookingListResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos
+	if !isAdmin && booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para editar esta reserva")
+	}
+
+	// Validar fechas
+	if !req.StartDatetime.Before(req.EndDatetime) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	targets, err := s.seriesOccurrences(booking, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo se pueden editar reservas pendientes, igual que en una reserva individual
+	var pending []models.Booking
+	for _, occurrence := range targets {
+		if occurrence.Status == models.StatusPending {
+			pending = append(pending, occurrence)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, errors.New("no hay reservas pendientes para editar en la serie")
+	}
+
+	// El cambio de la ocurrencia elegida se traslada al resto conservando el patrón en hora local
+	loc := booking.Resource.Location()
+	duration := req.EndDatetime.Sub(req.StartDatetime)
+
+	policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las ocurrencias movidas se evalúan sin contar su horario anterior
+	pendingIDs := make([]uint, 0, len(pending))
+	for _, occurrence := range pending {
+		pendingIDs = append(pendingIDs, occurrence.ID)
+	}
+	quota, err := s.quotaTracker(booking.UserID, pendingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	first := utils.ShiftWallClock(pending[0].StartDatetime, booking.StartDatetime, req.StartDatetime, loc)
+	last := utils.ShiftWallClock(pending[len(pending)-1].StartDatetime, booking.StartDatetime, req.StartDatetime, loc).Add(duration)
+	schedule, err := loadSchedule(s.availabilityRepo, s.blackoutRepo, &booking.Resource, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versiones anteriores de cada ocurrencia para el historial
+	previous := make([]models.Booking, len(pending))
+	copy(previous, pending)
+
+	for i := range pending {
+		start := utils.ShiftWallClock(pending[i].StartDatetime, booking.StartDatetime, req.StartDatetime, loc).UTC()
+		end := start.Add(duration)
+
+		if start.Before(time.Now()) {
+			return nil, fmt.Errorf("ocurrencia del %s: no se pueden crear reservas en el pasado", start.Format("2006-01-02 15:04"))
+		}
+		if err := checkBookingPolicy(policy, start, end, time.Now(), loc); err != nil {
+			return nil, fmt.Errorf("ocurrencia del %s: %w", start.Format("2006-01-02 15:04"), err)
+		}
+		if err := schedule.check(start, end); err != nil {
+			return nil, fmt.Errorf("ocurrencia del %s: %w", start.Format("2006-01-02 15:04"), err)
+		}
+		if err := quota.admit(models.Booking{StartDatetime: start, EndDatetime: end, Status: pending[i].Status}, &booking.Resource); err != nil {
+			return nil, fmt.Errorf("ocurrencia del %s: %w", start.Format("2006-01-02 15:04"), err)
+		}
+
+		pending[i].StartDatetime = start
+		pending[i].EndDatetime = end
+		pending[i].Notes = req.Notes
+		pending[i].TotalPrice = s.calculatePrice(&pending[i].Resource, pending[i].Seats, start, end)
+	}
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.LockResource(booking.ResourceID); err != nil {
+			return err
+		}
+
+		for i := range pending {
+			// Las ocurrencias aún no movidas se excluyen; las ya movidas se comparan con su nuevo horario
+			var excludeIDs []uint
+			for _, remaining := range pending[i:] {
+				excludeIDs = append(excludeIDs, remaining.ID)
+			}
+
+			if err := checkConflict(txRepo, &pending[i].Resource, pending[i].StartDatetime, pending[i].EndDatetime, pending[i].Seats, excludeIDs); err != nil {
+				if errors.Is(err, ErrBookingConflict) {
+					return fmt.Errorf("%w (ocurrencia del %s)", err, pending[i].StartDatetime.Format("2006-01-02 15:04"))
+				}
+				return err
+			}
+
+			if err := txRepo.Update(&pending[i]); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, &previous[i], &pending[i], &userID, req.Reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return nil, err
+		}
+		return nil, errors.New("error al actualizar la serie de reservas")
+	}
+
+	return s.mapToListResponse(pending), nil
+}
+
+// CancelBookingSeries cancela esta y las siguientes ocurrencias, o toda la serie.
+// Cada ocurrencia registra su propio cargo según su antelación y su evento en el historial.
+func (s *BookingService) CancelBookingSeries(id uint, userID uint, scope string, isAdmin bool, waiveFee bool, reason string) (int, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Verificar permisos
+	if !isAdmin && booking.UserID != userID {
+		return 0, errors.New("no tienes permisos para cancelar esta reserva")
+	}
+
+	targets, err := s.seriesOccurrences(booking, scope)
+	if err != nil {
+		return 0, err
+	}
+
+	policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+	if err != nil {
+		return 0, err
+	}
+
+	// Las ocurrencias que ya pasaron el plazo de cancelación se mantienen (salvo para admin)
+	now := time.Now()
+	var policyErr error
+	if !isAdmin {
+		var allowed []models.Booking
+		for _, occurrence := range targets {
+			if err := checkCancellationPolicy(policy, occurrence.StartDatetime, now); err != nil {
+				policyErr = err
+				continue
+			}
+			allowed = append(allowed, occurrence)
+		}
+		targets = allowed
+	}
+
+	var cancelled []models.Booking
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		for i := range targets {
+			// Solo se pueden cancelar reservas pending o confirmed
+			if targets[i].Status != models.StatusPending && targets[i].Status != models.StatusConfirmed {
+				continue
+			}
+			before := targets[i]
+			cancelWithCharge(&targets[i], policy, isAdmin && waiveFee, now)
+			if err := txRepo.Update(&targets[i]); err != nil {
+				return err
+			}
+			if err := recordEvent(txRepo, &before, &targets[i], &userID, reason); err != nil {
+				return err
+			}
+			cancelled = append(cancelled, targets[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.New("error al cancelar la serie de reservas")
+	}
+
+	if len(cancelled) == 0 {
+		if policyErr != nil {
+			return 0, policyErr
+		}
+		return 0, errors.New("no hay reservas activas para cancelar en la serie")
+	}
+
+	for i := range cancelled {
+		s.promoteWaitlist(&cancelled[i].Resource, cancelled[i].StartDatetime, cancelled[i].EndDatetime)
+	}
+
+	return len(cancelled), nil
+}
+
+// seriesOccurrences obtiene las ocurrencias futuras de la serie según el alcance
+func (s *BookingService) seriesOccurrences(booking *models.Booking, scope string) ([]models.Booking, error) {
+	if booking.SeriesID == nil {
+		return nil, errors.New("la reserva no pertenece a una serie")
+	}
+
+	from := time.Now()
+	switch scope {
+	case SeriesScopeFollowing:
+		if booking.StartDatetime.After(from) {
+			from = booking.StartDatetime
+		}
+	case SeriesScopeAll:
+	default:
+		return nil, fmt.Errorf("alcance inválido: %s", scope)
+	}
+
+	return s.bookingRepo.FindBySeriesID(*booking.SeriesID, from)
+}
+
+// buildRecurrenceRule convierte el DTO de recurrencia en una regla utilizable
+func buildRecurrenceRule(req *dto.RecurrenceRequest) (utils.RecurrenceRule, error) {
+	days, err := utils.ParseWeekdays(req.ByDay)
+	if err != nil {
+		return utils.RecurrenceRule{}, err
+	}
+
+	interval := req.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	rule := utils.RecurrenceRule{
+		Frequency: req.Frequency,
+		Interval:  interval,
+		ByDay:     days,
+		Count:     req.Count,
+		Until:     req.Until,
+	}
+
+	return rule, rule.Validate()
+}
@@ -11,21 +11,42 @@ import (
 	"time"
 )
 
+// ErrBookingConflict indica que otra reserva activa ocupa el horario solicitado
+var ErrBookingConflict = errors.New("el recurso no está disponible en ese horario")
+
 type BookingService struct {
-	bookingRepo  *repositories.BookingRepository
-	resourceRepo *repositories.ResourceRepository
-	userRepo     *repositories.UserRepository
+	bookingRepo      *repositories.BookingRepository
+	resourceRepo     *repositories.ResourceRepository
+	userRepo         *repositories.UserRepository
+	availabilityRepo *repositories.AvailabilityRepository
+	blackoutRepo     *repositories.BlackoutRepository
+	policyRepo       *repositories.BookingPolicyRepository
+	quotaRepo        *repositories.QuotaRepository
+	waitlistRepo     *repositories.WaitlistRepository
+	notificationRepo *repositories.NotificationRepository
 }
 
 func NewBookingService(
 	bookingRepo *repositories.BookingRepository,
 	resourceRepo *repositories.ResourceRepository,
 	userRepo *repositories.UserRepository,
+	availabilityRepo *repositories.AvailabilityRepository,
+	blackoutRepo *repositories.BlackoutRepository,
+	policyRepo *repositories.BookingPolicyRepository,
+	quotaRepo *repositories.QuotaRepository,
+	waitlistRepo *repositories.WaitlistRepository,
+	notificationRepo *repositories.NotificationRepository,
 ) *BookingService {
 	return &BookingService{
-		bookingRepo:  bookingRepo,
-		resourceRepo: resourceRepo,
-		userRepo:     userRepo,
+		bookingRepo:      bookingRepo,
+		resourceRepo:     resourceRepo,
+		userRepo:         userRepo,
+		availabilityRepo: availabilityRepo,
+		blackoutRepo:     blackoutRepo,
+		policyRepo:       policyRepo,
+		quotaRepo:        quotaRepo,
+		waitlistRepo:     waitlistRepo,
+		notificationRepo: notificationRepo,
 	}
 }
 
@@ -46,7 +67,30 @@ func (s *BookingService) GetMyBookings(userID uint, params utils.PaginationParam
 		return nil, 0, err
 	}
 
-	return s.mapToListResponse(bookings), total, nil
+	response := s.mapToListResponse(bookings)
+
+	// Las reservas a las que el usuario está invitado muestran su respuesta
+	var invitedIDs []uint
+	for _, booking := range bookings {
+		if booking.UserID != userID {
+			invitedIDs = append(invitedIDs, booking.ID)
+		}
+	}
+	attendances, err := s.bookingRepo.FindAttendances(userID, invitedIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	rsvp := make(map[uint]models.RSVPStatus, len(attendances))
+	for _, attendance := range attendances {
+		rsvp[attendance.BookingID] = attendance.RSVPStatus
+	}
+	for i := range response {
+		if status, ok := rsvp[response[i].ID]; ok {
+			response[i].RSVPStatus = string(status)
+		}
+	}
+
+	return response, total, nil
 }
 
 // GetBookingByID obtiene una reserva por ID
@@ -56,12 +100,19 @@ func (s *BookingService) GetBookingByID(id uint, userID uint, isAdmin bool) (*dt
 		return nil, err
 	}
 
-	// Verificar permisos: solo el dueño o admin pueden ver
-	if !isAdmin && booking.UserID != userID {
-		return nil, errors.New("no tienes permisos para ver esta reserva")
+	// Verificar permisos: el dueño, un admin o un invitado (solo lectura) pueden ver
+	if err := s.checkCanView(booking, userID, isAdmin); err != nil {
+		return nil, err
 	}
 
-	return s.mapToResponse(booking), nil
+	attendees, err := s.bookingRepo.FindAttendees(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.mapToResponse(booking)
+	response.Attendees = mapAttendees(attendees)
+	return response, nil
 }
 
 // CreateBooking crea una nueva reserva
@@ -85,37 +136,91 @@ func (s *BookingService) CreateBooking(userID uint, req *dto.CreateBookingReques
 		return nil, errors.New("el recurso no está disponible")
 	}
 
-	// Verificar disponibilidad (no hay solapamiento)
-	overlap, err := s.bookingRepo.CheckOverlap(req.ResourceID, req.StartDatetime, req.EndDatetime, nil)
-	if err != nil {
-		return nil, err
-	}
-	if overlap {
-		return nil, errors.New("el recurso no está disponible en ese horario")
-	}
-
-	// Calcular precio total
-	totalPrice := s.calculatePrice(resource.PricePerHour, req.StartDatetime, req.EndDatetime)
-
-	// Crear la reserva
 	booking := &models.Booking{
 		UserID:        userID,
-		ResourceID:    req.ResourceID,
 		StartDatetime: req.StartDatetime,
 		EndDatetime:   req.EndDatetime,
-		Status:        models.StatusPending,
-		TotalPrice:    totalPrice,
+		Seats:         req.Seats,
 		Notes:         req.Notes,
 	}
-
-	if err := s.bookingRepo.Create(booking); err != nil {
-		return nil, errors.New("error al crear la reserva")
+	if err := s.book(booking, resource, &userID, "", false); err != nil {
+		return nil, err
 	}
 
 	// Obtener la reserva completa con relaciones
 	return s.GetBookingByID(booking.ID, userID, false)
 }
 
+// book valida plazas, política, cuotas y horarios, y crea la reserva si el horario está libre.
+// Queda pendiente o confirmada según el modo de aprobación del recurso; las retenciones siempre pendientes.
+// booking trae el titular, el horario, las plazas pedidas y las notas; el resto se completa aquí.
+// La creación queda en el historial a nombre de actorID (nil si la hizo el sistema).
+// override (solo admin) omite la antelación, el horizonte y las cuotas del titular.
+func (s *BookingService) book(booking *models.Booking, resource *models.Resource, actorID *uint, reason string, override bool) error {
+	start, end := booking.StartDatetime, booking.EndDatetime
+
+	// Validar plazas solicitadas
+	seats, err := resolveSeats(resource, booking.Seats)
+	if err != nil {
+		return err
+	}
+
+	// Verificar la política de reservas del recurso
+	if err := s.validatePolicy(resource, start, end, override); err != nil {
+		return err
+	}
+
+	// Verificar las cuotas del usuario
+	if !override {
+		if err := s.validateQuota(booking.UserID, resource, start, end, nil); err != nil {
+			return err
+		}
+	}
+
+	// Verificar que el horario esté dentro de los horarios de atención
+	if err := s.validateOpeningHours(resource, start, end); err != nil {
+		return err
+	}
+
+	// Completar la reserva (las fechas se guardan siempre en UTC)
+	booking.ResourceID = resource.ID
+	booking.StartDatetime = start.UTC()
+	booking.EndDatetime = end.UTC()
+	booking.Status = models.StatusPending
+	if !booking.IsHold {
+		booking.Status = resource.InitialStatus()
+	}
+	booking.Seats = seats
+	booking.TotalPrice = s.calculatePrice(resource, seats, start, end)
+	if booking.ExpiresAt == nil {
+		booking.ExpiresAt = resource.PendingExpiry(time.Now())
+	}
+
+	// Verificar solapamiento y crear en la misma transacción, con el recurso bloqueado
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.LockResource(booking.ResourceID); err != nil {
+			return err
+		}
+
+		if err := checkConflict(txRepo, resource, booking.StartDatetime, booking.EndDatetime, booking.Seats, nil); err != nil {
+			return err
+		}
+
+		if err := txRepo.Create(booking); err != nil {
+			return err
+		}
+		return recordEvent(txRepo, nil, booking, actorID, reason)
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return err
+		}
+		return errors.New("error al crear la reserva")
+	}
+
+	return nil
+}
+
 // UpdateBooking actualiza una reserva
 func (s *BookingService) UpdateBooking(id uint, userID uint, req *dto.UpdateBookingRequest, isAdmin bool) (*dto.BookingResponse, error) {
 	// Buscar la reserva
@@ -129,84 +234,254 @@ func (s *BookingService) UpdateBooking(id uint, userID uint, req *dto.UpdateBook
 		return nil, errors.New("no tienes permisos para editar esta reserva")
 	}
 
+	// Las reservas de una reserva múltiple se gestionan en conjunto
+	if !isAdmin && booking.ReservationID != nil {
+		return nil, ErrReservationItem
+	}
+
 	// Solo se pueden editar reservas pendientes
 	if booking.Status != models.StatusPending {
 		return nil, errors.New("solo se pueden editar reservas pendientes")
 	}
 
+	if err := s.reschedule(booking, req, userID, false); err != nil {
+		return nil, err
+	}
+
+	return s.GetBookingByID(booking.ID, userID, isAdmin)
+}
+
+// reschedule valida y aplica el nuevo horario, plazas y notas de una reserva, registrando el cambio a nombre de actorID.
+// override (solo admin) permite horarios pasados y omite la antelación, el horizonte y las cuotas del titular.
+func (s *BookingService) reschedule(booking *models.Booking, req *dto.UpdateBookingRequest, actorID uint, override bool) error {
+	id := booking.ID
+
 	// Validar fechas
 	if req.StartDatetime.After(req.EndDatetime) || req.StartDatetime.Equal(req.EndDatetime) {
-		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+		return errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
 	}
 
-	if req.StartDatetime.Before(time.Now()) {
-		return nil, errors.New("no se pueden crear reservas en el pasado")
+	if !override && req.StartDatetime.Before(time.Now()) {
+		return errors.New("no se pueden crear reservas en el pasado")
 	}
 
-	// Verificar disponibilidad (excluyendo esta reserva)
-	overlap, err := s.bookingRepo.CheckOverlap(booking.ResourceID, req.StartDatetime, req.EndDatetime, &id)
+	// Obtener recurso para validar horarios y recalcular precio
+	resource, err := s.resourceRepo.FindByID(booking.ResourceID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	// Verificar la política de reservas del recurso
+	if err := s.validatePolicy(resource, req.StartDatetime, req.EndDatetime, override); err != nil {
+		return err
+	}
+
+	// Verificar las cuotas del titular sin contar la propia reserva
+	if !override {
+		if err := s.validateQuota(booking.UserID, resource, req.StartDatetime, req.EndDatetime, []uint{id}); err != nil {
+			return err
+		}
+	}
+
+	// Verificar que el nuevo horario esté dentro de los horarios de atención
+	if err := s.validateOpeningHours(resource, req.StartDatetime, req.EndDatetime); err != nil {
+		return err
 	}
-	if overlap {
-		return nil, errors.New("el recurso no está disponible en ese horario")
+
+	// Conservar las plazas actuales si no se indican nuevas
+	seats := booking.Seats
+	if req.Seats > 0 {
+		if seats, err = resolveSeats(resource, req.Seats); err != nil {
+			return err
+		}
 	}
 
-	// Obtener recurso para recalcular precio
-	resource, _ := s.resourceRepo.FindByID(booking.ResourceID)
+	// En recursos compartidos, las plazas deben alcanzar para el titular y sus invitados
+	if seats < booking.Seats && resource.BookingMode == models.BookingModeShared {
+		attendees, err := s.bookingRepo.FindAttendees(booking.ID)
+		if err != nil {
+			return err
+		}
+		if headcount := models.Headcount(attendees); seats < headcount {
+			return fmt.Errorf("la reserva tiene %d personas contando al titular y los invitados", headcount)
+		}
+	}
 
 	// Actualizar campos
-	booking.StartDatetime = req.StartDatetime
-	booking.EndDatetime = req.EndDatetime
+	before := *booking
+	previousStart, previousEnd := booking.StartDatetime, booking.EndDatetime
+	booking.StartDatetime = req.StartDatetime.UTC()
+	booking.EndDatetime = req.EndDatetime.UTC()
 	booking.Notes = req.Notes
-	booking.TotalPrice = s.calculatePrice(resource.PricePerHour, req.StartDatetime, req.EndDatetime)
+	booking.Seats = seats
+	booking.TotalPrice = s.calculatePrice(resource, seats, req.StartDatetime, req.EndDatetime)
 
-	if err := s.bookingRepo.Update(booking); err != nil {
-		return nil, errors.New("error al actualizar la reserva")
+	// Verificar solapamiento (excluyendo esta reserva) y guardar de forma atómica
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.LockResource(booking.ResourceID); err != nil {
+			return err
+		}
+
+		if err := checkConflict(txRepo, resource, booking.StartDatetime, booking.EndDatetime, booking.Seats, []uint{id}); err != nil {
+			return err
+		}
+
+		if err := txRepo.Update(booking); err != nil {
+			return err
+		}
+		return recordEvent(txRepo, &before, booking, &actorID, req.Reason)
+	})
+	if err != nil {
+		if errors.Is(err, ErrBookingConflict) {
+			return err
+		}
+		return errors.New("error al actualizar la reserva")
 	}
 
-	return s.GetBookingByID(booking.ID, userID, isAdmin)
+	// El horario anterior puede haber quedado libre para la lista de espera
+	s.promoteWaitlist(resource, previousStart, previousEnd)
+
+	return nil
 }
 
-// CancelBooking cancela una reserva
-func (s *BookingService) CancelBooking(id uint, userID uint, isAdmin bool) error {
+// CancelBooking cancela una reserva y registra el cargo y el reembolso según la política.
+// Solo un admin puede condonar el cargo (waiveFee). reason es opcional y queda en el historial.
+func (s *BookingService) CancelBooking(id uint, userID uint, isAdmin bool, waiveFee bool, reason string) (*dto.BookingResponse, error) {
 	booking, err := s.bookingRepo.FindByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Verificar permisos
 	if !isAdmin && booking.UserID != userID {
-		return errors.New("no tienes permisos para cancelar esta reserva")
+		return nil, errors.New("no tienes permisos para cancelar esta reserva")
+	}
+
+	// Las reservas de una reserva múltiple se gestionan en conjunto
+	if !isAdmin && booking.ReservationID != nil {
+		return nil, ErrReservationItem
 	}
 
 	// Solo se pueden cancelar reservas pending o confirmed
 	if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
-		return errors.New("solo se pueden cancelar reservas pendientes o confirmadas")
+		return nil, errors.New("solo se pueden cancelar reservas pendientes o confirmadas")
+	}
+
+	policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// El plazo de cancelación solo limita a los usuarios
+	now := time.Now()
+	if !isAdmin {
+		if err := checkCancellationPolicy(policy, booking.StartDatetime, now); err != nil {
+			return nil, err
+		}
 	}
 
 	// Cambiar estado a cancelled
-	booking.Status = models.StatusCancelled
-	return s.bookingRepo.Update(booking)
+	before := *booking
+	cancelWithCharge(booking, policy, isAdmin && waiveFee, now)
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.Update(booking); err != nil {
+			return err
+		}
+		return recordEvent(txRepo, &before, booking, &userID, reason)
+	})
+	if err != nil {
+		return nil, errors.New("error al cancelar la reserva")
+	}
+
+	s.promoteWaitlist(&booking.Resource, booking.StartDatetime, booking.EndDatetime)
+	return s.mapToResponse(booking), nil
 }
 
-// ChangeBookingStatus cambia el estado de una reserva (solo admin)
-func (s *BookingService) ChangeBookingStatus(id uint, status string) (*dto.BookingResponse, error) {
+// PreviewCancellation muestra si el usuario puede cancelar ahora y cuánto se le cobraría
+func (s *BookingService) PreviewCancellation(id uint, userID uint, isAdmin bool, waiveFee bool) (*dto.CancellationPreview, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar permisos
+	if !isAdmin && booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para ver esta reserva")
+	}
+
+	policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	preview := &dto.CancellationPreview{
+		BookingID:  booking.ID,
+		Allowed:    true,
+		TotalPrice: booking.TotalPrice,
+		FeeWaived:  isAdmin && waiveFee,
+	}
+
+	if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
+		preview.Allowed = false
+		preview.Reason = "solo se pueden cancelar reservas pendientes o confirmadas"
+	} else if !isAdmin {
+		if err := checkCancellationPolicy(policy, booking.StartDatetime, now); err != nil {
+			preview.Allowed = false
+			preview.Reason = err.Error()
+		}
+	}
+
+	preview.FeePercent, preview.CancelFee, preview.RefundAmount = cancellationCharge(policy, booking.TotalPrice, booking.StartDatetime, now)
+	if preview.FeeWaived {
+		preview.CancelFee = 0
+		preview.RefundAmount = booking.TotalPrice
+	}
+
+	return preview, nil
+}
+
+// ChangeBookingStatus cambia el estado de una reserva (solo admin) y lo registra en el historial.
+// Al cancelar se aplica el cargo de la política salvo que se condone (WaiveFee).
+func (s *BookingService) ChangeBookingStatus(id uint, adminID uint, req *dto.ChangeBookingStatusRequest) (*dto.BookingResponse, error) {
 	booking, err := s.bookingRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Validar transiciones de estado
-	if err := s.validateStatusTransition(booking.Status, models.BookingStatus(status)); err != nil {
+	if err := s.validateStatusTransition(booking.Status, models.BookingStatus(req.Status)); err != nil {
 		return nil, err
 	}
 
-	booking.Status = models.BookingStatus(status)
-	if err := s.bookingRepo.Update(booking); err != nil {
+	before := *booking
+	booking.Status = models.BookingStatus(req.Status)
+	if booking.Status == models.StatusCancelled {
+		policy, err := loadPolicy(s.policyRepo, &booking.Resource)
+		if err != nil {
+			return nil, err
+		}
+		cancelWithCharge(booking, policy, req.WaiveFee, time.Now())
+	}
+	if booking.Status == models.StatusConfirmed {
+		// Una reserva confirmada ya no caduca
+		booking.IsHold = false
+		booking.ExpiresAt = nil
+	}
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		if err := txRepo.Update(booking); err != nil {
+			return err
+		}
+		return recordEvent(txRepo, &before, booking, &adminID, req.Reason)
+	})
+	if err != nil {
 		return nil, errors.New("error al cambiar el estado")
 	}
 
+	if booking.Status == models.StatusCancelled {
+		s.promoteWaitlist(&booking.Resource, booking.StartDatetime, booking.EndDatetime)
+	}
+
 	return s.mapToResponse(booking), nil
 }
 
@@ -216,20 +491,24 @@ func (s *BookingService) GetBookingStats() (*dto.BookingStatsResponse, error) {
 	confirmed, _ := s.bookingRepo.CountByStatus("confirmed")
 	cancelled, _ := s.bookingRepo.CountByStatus("cancelled")
 	completed, _ := s.bookingRepo.CountByStatus("completed")
+	noShow, _ := s.bookingRepo.CountByStatus("no_show")
 
 	totalRevenue, _ := s.bookingRepo.GetTotalRevenue()
 	pendingRevenue, _ := s.bookingRepo.GetRevenueByStatus("pending")
 	confirmedRevenue, _ := s.bookingRepo.GetRevenueByStatus("confirmed")
+	cancellationFees, _ := s.bookingRepo.GetCancellationFeeTotal()
 
 	stats := &dto.BookingStatsResponse{
-		TotalBookings:     pending + confirmed + cancelled + completed,
+		TotalBookings:     pending + confirmed + cancelled + completed + noShow,
 		PendingBookings:   pending,
 		ConfirmedBookings: confirmed,
 		CancelledBookings: cancelled,
 		CompletedBookings: completed,
+		NoShowBookings:    noShow,
 		TotalRevenue:      totalRevenue,
 		PendingRevenue:    pendingRevenue,
 		ConfirmedRevenue:  confirmedRevenue,
+		CancellationFees:  cancellationFees,
 	}
 
 	return stats, nil
@@ -246,24 +525,140 @@ func (s *BookingService) GetUpcomingBookings(userID uint) ([]dto.BookingListResp
 
 // ============= FUNCIONES AUXILIARES =============
 
-// calculatePrice calcula el precio total basado en las horas
-func (s *BookingService) calculatePrice(pricePerHour float64, start, end time.Time) float64 {
+// calculatePrice calcula el precio total basado en las horas (y en las plazas si el recurso cobra por plaza)
+func (s *BookingService) calculatePrice(resource *models.Resource, seats int, start, end time.Time) float64 {
 	duration := end.Sub(start)
 	hours := duration.Hours()
 
 	// Redondear hacia arriba (si reservas 1.5 horas, pagas 2 horas)
 	hoursRounded := math.Ceil(hours)
 
-	return pricePerHour * hoursRounded
+	price := resource.PricePerHour * hoursRounded
+	if resource.PricePerSeat {
+		price *= float64(seats)
+	}
+
+	return price
+}
+
+// resolveSeats aplica el valor por defecto y valida las plazas contra la capacidad del recurso
+func resolveSeats(resource *models.Resource, requested int) (int, error) {
+	seats := requested
+	if seats <= 0 {
+		seats = 1
+	}
+	if seats > resource.Capacity {
+		return 0, fmt.Errorf("el recurso admite como máximo %d plazas", resource.Capacity)
+	}
+	return seats, nil
+}
+
+// checkConflict verifica que la reserva quepa en el recurso según su modo de reserva
+func checkConflict(txRepo *repositories.BookingRepository, resource *models.Resource, start, end time.Time, seats int, excludeIDs []uint) error {
+	// Los márgenes de preparación y limpieza separan las reservas sin cambiar sus horarios
+	gap := resource.TurnoverGap()
+
+	// Recursos exclusivos: cualquier solapamiento es un conflicto
+	if resource.BookingMode != models.BookingModeShared {
+		overlap, err := txRepo.CheckOverlapExcluding(resource.ID, start.Add(-gap), end.Add(gap), excludeIDs)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return ErrBookingConflict
+		}
+		return nil
+	}
+
+	// Recursos compartidos: la suma de plazas no puede superar la capacidad en ningún instante
+	overlapping, err := txRepo.FindOverlapping(resource.ID, start.Add(-gap), end.Add(gap), excludeIDs)
+	if err != nil {
+		return err
+	}
+
+	booked := utils.PeakLoad(bookingLoads(overlapping, gap), utils.TimeRange{Start: start, End: end})
+	if booked+seats > resource.Capacity {
+		remaining := resource.Capacity - booked
+		if remaining < 0 {
+			remaining = 0
+		}
+		return fmt.Errorf("%w: quedan %d de %d plazas", ErrBookingConflict, remaining, resource.Capacity)
+	}
+
+	return nil
+}
+
+// bookingLoads convierte reservas en ocupaciones por plazas, extendidas gap hacia ambos lados
+func bookingLoads(bookings []models.Booking, gap time.Duration) []utils.Load {
+	loads := make([]utils.Load, 0, len(bookings))
+	for _, booking := range bookings {
+		seats := booking.Seats
+		if seats <= 0 {
+			seats = 1
+		}
+		loads = append(loads, utils.Load{
+			TimeRange: utils.TimeRange{Start: booking.StartDatetime.Add(-gap), End: booking.EndDatetime.Add(gap)},
+			Amount:    seats,
+		})
+	}
+	return loads
+}
+
+// validatePolicy verifica la política de reservas efectiva del recurso.
+// Con override (solo admin) se omiten la antelación mínima y el horizonte, no la duración ni la granularidad.
+func (s *BookingService) validatePolicy(resource *models.Resource, start, end time.Time, override bool) error {
+	policy, err := loadPolicy(s.policyRepo, resource)
+	if err != nil {
+		return err
+	}
+
+	if override {
+		if err := checkDurationPolicy(policy, start, end); err != nil {
+			return err
+		}
+		return checkGranularityPolicy(policy, start, resource.Location())
+	}
+	return checkBookingPolicy(policy, start, end, time.Now(), resource.Location())
+}
+
+// validateQuota verifica que la reserva quepa en las cuotas del usuario
+func (s *BookingService) validateQuota(userID uint, resource *models.Resource, start, end time.Time, excludeIDs []uint) error {
+	tracker, err := s.quotaTracker(userID, excludeIDs)
+	if err != nil {
+		return err
+	}
+
+	return tracker.admit(models.Booking{StartDatetime: start, EndDatetime: end, Status: models.StatusPending}, resource)
+}
+
+// quotaTracker carga las cuotas y reservas del usuario para evaluar una o varias reservas nuevas
+func (s *BookingService) quotaTracker(userID uint, excludeIDs []uint) (*quotaTracker, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newQuotaTracker(s.quotaRepo, s.bookingRepo, user, excludeIDs)
+}
+
+// validateOpeningHours verifica que todo el intervalo esté cubierto por los horarios del recurso y fuera de cierres
+func (s *BookingService) validateOpeningHours(resource *models.Resource, start, end time.Time) error {
+	schedule, err := loadSchedule(s.availabilityRepo, s.blackoutRepo, resource, start, end)
+	if err != nil {
+		return err
+	}
+
+	return schedule.check(start, end)
 }
 
 // validateStatusTransition valida las transiciones de estado permitidas
 func (s *BookingService) validateStatusTransition(currentStatus, newStatus models.BookingStatus) error {
 	validTransitions := map[models.BookingStatus][]models.BookingStatus{
 		models.StatusPending:   {models.StatusConfirmed, models.StatusCancelled},
-		models.StatusConfirmed: {models.StatusCompleted, models.StatusCancelled},
+		models.StatusConfirmed: {models.StatusCompleted, models.StatusNoShow, models.StatusCancelled},
 		models.StatusCancelled: {}, // No se puede cambiar desde cancelled
 		models.StatusCompleted: {}, // No se puede cambiar desde completed
+		models.StatusNoShow:    {}, // No se puede cambiar desde no_show
 	}
 
 	allowed, exists := validTransitions[currentStatus]
@@ -282,6 +677,8 @@ func (s *BookingService) validateStatusTransition(currentStatus, newStatus model
 
 // mapToResponse convierte un modelo a DTO completo
 func (s *BookingService) mapToResponse(booking *models.Booking) *dto.BookingResponse {
+	loc := booking.Resource.Location()
+
 	return &dto.BookingResponse{
 		ID:     booking.ID,
 		UserID: booking.UserID,
@@ -303,14 +700,33 @@ func (s *BookingService) mapToResponse(booking *models.Booking) *dto.BookingResp
 			Category:     booking.Resource.Category,
 			ImageURL:     booking.Resource.ImageURL,
 			IsActive:     booking.Resource.IsActive,
+			BookingMode:  string(booking.Resource.BookingMode),
+			PricePerSeat: booking.Resource.PricePerSeat,
+			Timezone:     booking.Resource.Timezone,
+			ApprovalMode: string(booking.Resource.ApprovalMode),
 			CreatedAt:    booking.Resource.CreatedAt,
 			UpdatedAt:    booking.Resource.UpdatedAt,
 		},
-		StartDatetime: booking.StartDatetime,
-		EndDatetime:   booking.EndDatetime,
+		StartDatetime: booking.StartDatetime.UTC(),
+		EndDatetime:   booking.EndDatetime.UTC(),
+		Timezone:      loc.String(),
+		StartLocal:    booking.StartDatetime.In(loc),
+		EndLocal:      booking.EndDatetime.In(loc),
 		Status:        string(booking.Status),
+		Seats:         booking.Seats,
 		TotalPrice:    booking.TotalPrice,
 		Notes:         booking.Notes,
+		SeriesID:      booking.SeriesID,
+		ReservationID: booking.ReservationID,
+		IsHold:        booking.IsHold,
+		ExpiresAt:     booking.ExpiresAt,
+		CancelReason:  booking.CancelReason,
+		CheckedInAt:   booking.CheckedInAt,
+		DecidedBy:     booking.DecidedBy,
+		DecidedAt:     booking.DecidedAt,
+		DecisionNote:  booking.DecisionNote,
+		CancelFee:     booking.CancelFee,
+		RefundAmount:  booking.RefundAmount,
 		CreatedAt:     booking.CreatedAt,
 		UpdatedAt:     booking.UpdatedAt,
 	}
@@ -320,16 +736,23 @@ func (s *BookingService) mapToResponse(booking *models.Booking) *dto.BookingResp
 func (s *BookingService) mapToListResponse(bookings []models.Booking) []dto.BookingListResponse {
 	var response []dto.BookingListResponse
 	for _, booking := range bookings {
+		loc := booking.Resource.Location()
 		response = append(response, dto.BookingListResponse{
 			ID:            booking.ID,
 			UserID:        booking.UserID,
 			UserName:      booking.User.FullName,
 			ResourceID:    booking.ResourceID,
 			ResourceName:  booking.Resource.Name,
-			StartDatetime: booking.StartDatetime,
-			EndDatetime:   booking.EndDatetime,
+			StartDatetime: booking.StartDatetime.UTC(),
+			EndDatetime:   booking.EndDatetime.UTC(),
+			Timezone:      loc.String(),
+			StartLocal:    booking.StartDatetime.In(loc),
+			EndLocal:      booking.EndDatetime.In(loc),
 			Status:        string(booking.Status),
+			Seats:         booking.Seats,
 			TotalPrice:    booking.TotalPrice,
+			SeriesID:      booking.SeriesID,
+			ReservationID: booking.ReservationID,
 			CreatedAt:     booking.CreatedAt,
 		})
 	}
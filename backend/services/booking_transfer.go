@@ -0,0 +1,273 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// errTransferClosed indica que la transferencia ya no espera respuesta
+	errTransferClosed = errors.New("la transferencia ya no está pendiente")
+	// errNotTransferable indica que la reserva cambió de titular o de estado desde la propuesta
+	errNotTransferable = errors.New("la reserva ya no se puede transferir")
+)
+
+// ProposeTransfer propone pasar una reserva activa a otro usuario. Puede proponerla el titular o un admin;
+// el titular no cambia hasta que el destinatario la acepta.
+func (s *BookingService) ProposeTransfer(id uint, userID uint, isAdmin bool, req *dto.BookingTransferRequest) (*dto.BookingTransferResponse, error) {
+	booking, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && booking.UserID != userID {
+		return nil, errors.New("no tienes permisos para transferir esta reserva")
+	}
+
+	if err := checkTransferable(booking); err != nil {
+		return nil, err
+	}
+
+	if req.ToUserID == booking.UserID {
+		return nil, errors.New("la reserva ya es de ese usuario")
+	}
+	recipient, err := s.userRepo.FindByID(req.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.bookingRepo.HasPendingTransfer(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, errors.New("la reserva ya tiene una transferencia pendiente")
+	}
+
+	transfer := &models.BookingTransfer{
+		BookingID:  booking.ID,
+		FromUserID: booking.UserID,
+		ToUserID:   recipient.ID,
+		ProposedBy: userID,
+		Status:     models.TransferPending,
+		Message:    strings.TrimSpace(req.Message),
+	}
+	if err := s.bookingRepo.CreateTransfer(transfer); err != nil {
+		return nil, errors.New("error al proponer la transferencia")
+	}
+
+	_ = s.notificationRepo.Create(&models.Notification{
+		UserID:    recipient.ID,
+		BookingID: &booking.ID,
+		Message: fmt.Sprintf("%s quiere transferirte su reserva #%d en %s", booking.User.FullName, booking.ID,
+			booking.Resource.Name),
+	})
+
+	transfer.Booking = *booking
+	transfer.FromUser = booking.User
+	transfer.ToUser = *recipient
+	response := mapTransfer(*transfer)
+	return &response, nil
+}
+
+// GetMyTransfers obtiene las transferencias que el usuario propuso o recibió
+func (s *BookingService) GetMyTransfers(userID uint) ([]dto.BookingTransferResponse, error) {
+	transfers, err := s.bookingRepo.FindTransfersByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.BookingTransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		response = append(response, mapTransfer(transfer))
+	}
+	return response, nil
+}
+
+// AcceptTransfer hace al destinatario titular de la reserva tras comprobar sus cuotas, y avisa a
+// ambas partes. La reserva deja su serie, que sigue siendo del titular anterior.
+func (s *BookingService) AcceptTransfer(transferID uint, userID uint) (*dto.BookingResponse, error) {
+	transfer, err := s.bookingRepo.FindTransferByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToUserID != userID {
+		return nil, errors.New("la transferencia no es para ti")
+	}
+	if !transfer.IsPending() {
+		return nil, errTransferClosed
+	}
+
+	booking := &transfer.Booking
+	if booking.UserID != transfer.FromUserID || checkTransferable(booking) != nil {
+		// La reserva cambió desde la propuesta: la transferencia ya no tiene sentido
+		_, _ = s.bookingRepo.ResolveTransfer(transfer.ID, models.TransferCancelled, time.Now())
+		return nil, errNotTransferable
+	}
+
+	// Las cuotas se evalúan para el nuevo titular
+	if err := s.validateQuota(userID, &booking.Resource, booking.StartDatetime, booking.EndDatetime, nil); err != nil {
+		return nil, err
+	}
+
+	err = s.bookingRepo.WithTransaction(func(txRepo *repositories.BookingRepository) error {
+		resolved, err := txRepo.ResolveTransfer(transfer.ID, models.TransferAccepted, time.Now())
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			return errTransferClosed
+		}
+
+		transferred, err := txRepo.TransferOwnership(booking.ID, transfer.FromUserID, userID)
+		if err != nil {
+			return err
+		}
+		if !transferred {
+			return errNotTransferable
+		}
+
+		// El nuevo titular deja de figurar como invitado
+		if err := txRepo.DeleteAttendee(booking.ID, userID); err != nil {
+			return err
+		}
+
+		after := *booking
+		after.UserID = userID
+		after.SeriesID = nil
+		return recordEvent(txRepo, booking, &after, &userID, fmt.Sprintf("transferencia #%d aceptada", transfer.ID))
+	})
+	if err != nil {
+		if errors.Is(err, errTransferClosed) || errors.Is(err, errNotTransferable) {
+			return nil, err
+		}
+		return nil, errors.New("error al aceptar la transferencia")
+	}
+
+	s.notifyTransfer(transfer, transfer.FromUserID,
+		fmt.Sprintf("%s aceptó la transferencia de tu reserva #%d en %s", transfer.ToUser.FullName, booking.ID, booking.Resource.Name))
+	s.notifyTransfer(transfer, userID,
+		fmt.Sprintf("Ahora eres titular de la reserva #%d en %s", booking.ID, booking.Resource.Name))
+
+	return s.GetBookingByID(booking.ID, userID, false)
+}
+
+// DeclineTransfer rechaza una transferencia recibida y avisa a quien la propuso
+func (s *BookingService) DeclineTransfer(transferID uint, userID uint) (*dto.BookingTransferResponse, error) {
+	transfer, err := s.bookingRepo.FindTransferByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToUserID != userID {
+		return nil, errors.New("la transferencia no es para ti")
+	}
+
+	if err := s.resolveTransfer(transfer, models.TransferDeclined); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%s rechazó la transferencia de la reserva #%d en %s", transfer.ToUser.FullName,
+		transfer.BookingID, transfer.Booking.Resource.Name)
+	s.notifyTransfer(transfer, transfer.FromUserID, message)
+	if transfer.ProposedBy != transfer.FromUserID {
+		s.notifyTransfer(transfer, transfer.ProposedBy, message)
+	}
+
+	response := mapTransfer(*transfer)
+	return &response, nil
+}
+
+// CancelTransfer retira una transferencia pendiente; pueden hacerlo el titular, quien la propuso o un admin
+func (s *BookingService) CancelTransfer(transferID uint, userID uint, isAdmin bool) (*dto.BookingTransferResponse, error) {
+	transfer, err := s.bookingRepo.FindTransferByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && transfer.FromUserID != userID && transfer.ProposedBy != userID {
+		return nil, errors.New("no tienes permisos para retirar esta transferencia")
+	}
+
+	if err := s.resolveTransfer(transfer, models.TransferCancelled); err != nil {
+		return nil, err
+	}
+
+	s.notifyTransfer(transfer, transfer.ToUserID,
+		fmt.Sprintf("Se retiró la transferencia de la reserva #%d en %s", transfer.BookingID, transfer.Booking.Resource.Name))
+
+	response := mapTransfer(*transfer)
+	return &response, nil
+}
+
+// resolveTransfer cierra la transferencia con status si sigue pendiente y actualiza la copia en memoria
+func (s *BookingService) resolveTransfer(transfer *models.BookingTransfer, status models.TransferStatus) error {
+	if !transfer.IsPending() {
+		return errTransferClosed
+	}
+
+	now := time.Now()
+	resolved, err := s.bookingRepo.ResolveTransfer(transfer.ID, status, now)
+	if err != nil {
+		return errors.New("error al actualizar la transferencia")
+	}
+	if !resolved {
+		return errTransferClosed
+	}
+
+	transfer.Status = status
+	respondedAt := now.UTC()
+	transfer.RespondedAt = &respondedAt
+	return nil
+}
+
+// notifyTransfer avisa a uno de los usuarios involucrados en una transferencia
+func (s *BookingService) notifyTransfer(transfer *models.BookingTransfer, userID uint, message string) {
+	_ = s.notificationRepo.Create(&models.Notification{
+		UserID:    userID,
+		BookingID: &transfer.BookingID,
+		Message:   message,
+	})
+}
+
+// checkTransferable verifica que la reserva siga activa y se pueda transferir sola
+func checkTransferable(booking *models.Booking) error {
+	if booking.Status != models.StatusPending && booking.Status != models.StatusConfirmed {
+		return errors.New("solo se pueden transferir reservas pendientes o confirmadas")
+	}
+	if booking.IsHold {
+		return errors.New("no se puede transferir una retención")
+	}
+	if booking.ReservationID != nil {
+		return errors.New("las reservas de una reserva múltiple no se transfieren por separado")
+	}
+	if !time.Now().Before(booking.EndDatetime) {
+		return errors.New("la reserva ya terminó")
+	}
+	return nil
+}
+
+func mapTransfer(transfer models.BookingTransfer) dto.BookingTransferResponse {
+	return dto.BookingTransferResponse{
+		ID:            transfer.ID,
+		BookingID:     transfer.BookingID,
+		ResourceName:  transfer.Booking.Resource.Name,
+		StartDatetime: transfer.Booking.StartDatetime.UTC(),
+		EndDatetime:   transfer.Booking.EndDatetime.UTC(),
+		FromUserID:    transfer.FromUserID,
+		FromUserName:  transfer.FromUser.FullName,
+		ToUserID:      transfer.ToUserID,
+		ToUserName:    transfer.ToUser.FullName,
+		ProposedBy:    transfer.ProposedBy,
+		Status:        string(transfer.Status),
+		Message:       transfer.Message,
+		RespondedAt:   transfer.RespondedAt,
+		CreatedAt:     transfer.CreatedAt,
+	}
+}
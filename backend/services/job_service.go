@@ -0,0 +1,56 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"Reservify/utils"
+)
+
+type JobService struct {
+	jobRepo *repositories.JobRepository
+}
+
+func NewJobService(jobRepo *repositories.JobRepository) *JobService {
+	return &JobService{jobRepo: jobRepo}
+}
+
+// GetLatestRuns obtiene la última ejecución de cada tarea programada
+func (s *JobService) GetLatestRuns() ([]dto.JobRunResponse, error) {
+	runs, err := s.jobRepo.FindLatestRuns()
+	if err != nil {
+		return nil, err
+	}
+	return mapJobRuns(runs), nil
+}
+
+// GetRuns obtiene el historial de ejecuciones, opcionalmente filtrado por tarea
+func (s *JobService) GetRuns(job string, params utils.PaginationParams) ([]dto.JobRunResponse, int64, error) {
+	runs, total, err := s.jobRepo.FindRuns(job, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapJobRuns(runs), total, nil
+}
+
+// mapJobRuns convierte ejecuciones a DTOs
+func mapJobRuns(runs []models.JobRun) []dto.JobRunResponse {
+	response := make([]dto.JobRunResponse, 0, len(runs))
+	for _, run := range runs {
+		item := dto.JobRunResponse{
+			ID:         run.ID,
+			Job:        run.Job,
+			Instance:   run.Instance,
+			Status:     string(run.Status),
+			Processed:  run.Processed,
+			Error:      run.Error,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+		}
+		if run.FinishedAt != nil {
+			item.DurationMs = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+		}
+		response = append(response, item)
+	}
+	return response
+}
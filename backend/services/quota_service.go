@@ -0,0 +1,328 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Reglas de cuota, usadas para identificar qué límite se supera
+const (
+	QuotaRuleActiveBookings = "max_active_bookings"
+	QuotaRuleHoursPerDay    = "max_hours_per_day"
+	QuotaRuleHoursPerWeek   = "max_hours_per_week"
+	QuotaRuleHoursPerMonth  = "max_hours_per_month"
+)
+
+// quotaLookback cubre el inicio del mes en curso en cualquier zona horaria
+const quotaLookback = 32 * 24 * time.Hour
+
+// QuotaExceeded indica que una reserva superaría una cuota del usuario
+type QuotaExceeded struct {
+	Rule     string  `json:"rule"`
+	Category string  `json:"category,omitempty"`
+	Limit    float64 `json:"limit"`
+	Used     float64 `json:"used"`
+	Message  string  `json:"message"`
+}
+
+func (e *QuotaExceeded) Error() string {
+	return e.Message
+}
+
+type QuotaService struct {
+	quotaRepo   *repositories.QuotaRepository
+	userRepo    *repositories.UserRepository
+	bookingRepo *repositories.BookingRepository
+}
+
+func NewQuotaService(
+	quotaRepo *repositories.QuotaRepository,
+	userRepo *repositories.UserRepository,
+	bookingRepo *repositories.BookingRepository,
+) *QuotaService {
+	return &QuotaService{
+		quotaRepo:   quotaRepo,
+		userRepo:    userRepo,
+		bookingRepo: bookingRepo,
+	}
+}
+
+// GetAllQuotas obtiene todas las cuotas configuradas
+func (s *QuotaService) GetAllQuotas() ([]dto.QuotaResponse, error) {
+	quotas, err := s.quotaRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.QuotaResponse, 0, len(quotas))
+	for i := range quotas {
+		response = append(response, *mapQuotaResponse(&quotas[i]))
+	}
+	return response, nil
+}
+
+// SaveQuota crea o reemplaza la cuota de un usuario, de un rol o la global para una categoría
+func (s *QuotaService) SaveQuota(req *dto.QuotaRequest) (*dto.QuotaResponse, error) {
+	if req.UserID != nil && req.Role != "" {
+		return nil, errors.New("indica un usuario o un rol, no ambos")
+	}
+	if req.UserID != nil {
+		if _, err := s.userRepo.FindByID(*req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	quota, err := s.quotaRepo.FindByScope(req.UserID, req.Role, req.Category)
+	if err != nil {
+		quota = &models.BookingQuota{UserID: req.UserID, Role: models.UserRole(req.Role), Category: req.Category}
+	}
+
+	quota.MaxActiveBookings = req.MaxActiveBookings
+	quota.MaxHoursPerDay = req.MaxHoursPerDay
+	quota.MaxHoursPerWeek = req.MaxHoursPerWeek
+	quota.MaxHoursPerMonth = req.MaxHoursPerMonth
+
+	if err := s.quotaRepo.Save(quota); err != nil {
+		return nil, errors.New("error al guardar la cuota")
+	}
+
+	return mapQuotaResponse(quota), nil
+}
+
+// DeleteQuota elimina una cuota; el nivel afectado vuelve a heredar del más general
+func (s *QuotaService) DeleteQuota(id uint) error {
+	return s.quotaRepo.Delete(id)
+}
+
+// GetMyQuota muestra el uso actual del usuario frente a sus límites (día, semana y mes en UTC)
+func (s *QuotaService) GetMyQuota(userID uint) (*dto.QuotaUsageResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker, err := newQuotaTracker(s.quotaRepo, s.bookingRepo, user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.QuotaUsageResponse{UserID: user.ID, Role: string(user.Role), Usage: []dto.QuotaUsage{}}
+
+	categories := make([]string, 0, len(tracker.limits))
+	for category := range tracker.limits {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		limit := tracker.limits[category]
+		if limit.MaxActiveBookings != nil {
+			response.Usage = append(response.Usage, newQuotaUsage(QuotaRuleActiveBookings, category,
+				float64(*limit.MaxActiveBookings), float64(tracker.activeCount(category))))
+		}
+		for _, rule := range tracker.hourRules(limit, tracker.now, time.UTC) {
+			if rule.max != nil {
+				response.Usage = append(response.Usage, newQuotaUsage(rule.name, category,
+					*rule.max, tracker.hoursIn(category, rule.period)))
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// quotaTracker evalúa las cuotas en memoria sobre las reservas ya cargadas del usuario,
+// de modo que varias reservas nuevas (p. ej. una serie) se acumulan entre sí
+type quotaTracker struct {
+	limits   map[string]models.BookingQuota // Por categoría; "" aplica a todas
+	bookings []models.Booking
+	now      time.Time
+}
+
+// quotaRule es un límite de horas asociado a su período
+type quotaRule struct {
+	name   string
+	max    *float64
+	period [2]time.Time
+}
+
+// newQuotaTracker carga las cuotas efectivas y las reservas del usuario, sin las excluidas
+func newQuotaTracker(
+	quotaRepo *repositories.QuotaRepository,
+	bookingRepo *repositories.BookingRepository,
+	user *models.User,
+	excludeIDs []uint,
+) (*quotaTracker, error) {
+	quotas, err := quotaRepo.FindApplicable(user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tracker := &quotaTracker{limits: resolveQuotas(quotas), now: now}
+	if len(tracker.limits) == 0 {
+		return tracker, nil
+	}
+
+	bookings, err := bookingRepo.FindForQuota(user.ID, now.Add(-quotaLookback))
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[uint]bool, len(excludeIDs))
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+	for _, booking := range bookings {
+		if !excluded[booking.ID] {
+			tracker.bookings = append(tracker.bookings, booking)
+		}
+	}
+
+	return tracker, nil
+}
+
+// resolveQuotas combina las cuotas por categoría empezando por la más específica
+func resolveQuotas(quotas []models.BookingQuota) map[string]models.BookingQuota {
+	rank := map[string]int{"user": 0, "role": 1, "global": 2}
+	sort.Slice(quotas, func(i, j int) bool {
+		return rank[quotas[i].Scope()] < rank[quotas[j].Scope()]
+	})
+
+	limits := make(map[string]models.BookingQuota)
+	for _, quota := range quotas {
+		limits[quota.Category] = limits[quota.Category].Inherit(quota)
+	}
+	return limits
+}
+
+// admit comprueba que la reserva cabe en las cuotas y, si cabe, la suma al uso
+func (t *quotaTracker) admit(candidate models.Booking, resource *models.Resource) error {
+	categories := []string{""}
+	if resource.Category != "" {
+		categories = append(categories, resource.Category)
+	}
+
+	for _, category := range categories {
+		limit, ok := t.limits[category]
+		if !ok {
+			continue
+		}
+
+		if max := limit.MaxActiveBookings; max != nil {
+			if used := t.activeCount(category); used+1 > *max {
+				return newQuotaExceeded(QuotaRuleActiveBookings, category, float64(*max), float64(used),
+					fmt.Sprintf("ya tienes %d reservas activas (máximo %d)", used, *max))
+			}
+		}
+
+		// Los períodos se calculan en la zona horaria del recurso reservado
+		for _, rule := range t.hourRules(limit, candidate.StartDatetime, resource.Location()) {
+			if rule.max == nil {
+				continue
+			}
+			used := t.hoursIn(category, rule.period)
+			added := overlapHours(candidate.StartDatetime, candidate.EndDatetime, rule.period)
+			if used+added > *rule.max+1e-9 {
+				return newQuotaExceeded(rule.name, category, *rule.max, used,
+					fmt.Sprintf("superarías tu límite de %.1f horas (llevas %.1f)", *rule.max, used))
+			}
+		}
+	}
+
+	candidate.Resource = *resource
+	t.bookings = append(t.bookings, candidate)
+	return nil
+}
+
+// activeCount cuenta las reservas pending/confirmed que aún no terminaron
+func (t *quotaTracker) activeCount(category string) int {
+	count := 0
+	for _, booking := range t.bookings {
+		if !quotaMatches(booking, category) || !booking.EndDatetime.After(t.now) {
+			continue
+		}
+		if booking.Status == models.StatusPending || booking.Status == models.StatusConfirmed {
+			count++
+		}
+	}
+	return count
+}
+
+// hoursIn suma las horas reservadas de la categoría dentro del período
+func (t *quotaTracker) hoursIn(category string, period [2]time.Time) float64 {
+	total := 0.0
+	for _, booking := range t.bookings {
+		if quotaMatches(booking, category) {
+			total += overlapHours(booking.StartDatetime, booking.EndDatetime, period)
+		}
+	}
+	return total
+}
+
+// overlapHours devuelve las horas de [start, end) que caen dentro del período
+func overlapHours(start, end time.Time, period [2]time.Time) float64 {
+	if start.Before(period[0]) {
+		start = period[0]
+	}
+	if end.After(period[1]) {
+		end = period[1]
+	}
+	if !start.Before(end) {
+		return 0
+	}
+	return end.Sub(start).Hours()
+}
+
+// hourRules devuelve los límites de horas con el día, la semana (desde el lunes) y el mes que contienen at
+func (t *quotaTracker) hourRules(limit models.BookingQuota, at time.Time, loc *time.Location) []quotaRule {
+	local := at.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	week := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	month := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+
+	return []quotaRule{
+		{name: QuotaRuleHoursPerDay, max: limit.MaxHoursPerDay, period: [2]time.Time{day, day.AddDate(0, 0, 1)}},
+		{name: QuotaRuleHoursPerWeek, max: limit.MaxHoursPerWeek, period: [2]time.Time{week, week.AddDate(0, 0, 7)}},
+		{name: QuotaRuleHoursPerMonth, max: limit.MaxHoursPerMonth, period: [2]time.Time{month, month.AddDate(0, 1, 0)}},
+	}
+}
+
+// quotaMatches indica si la reserva cuenta para la categoría ("" cuenta todas)
+func quotaMatches(booking models.Booking, category string) bool {
+	return category == "" || booking.Resource.Category == category
+}
+
+func newQuotaExceeded(rule, category string, limit, used float64, message string) *QuotaExceeded {
+	if category != "" {
+		message = fmt.Sprintf("%s en la categoría %s", message, category)
+	}
+	return &QuotaExceeded{Rule: rule, Category: category, Limit: limit, Used: used, Message: message}
+}
+
+func newQuotaUsage(rule, category string, limit, used float64) dto.QuotaUsage {
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return dto.QuotaUsage{Rule: rule, Category: category, Limit: limit, Used: used, Remaining: remaining}
+}
+
+func mapQuotaResponse(quota *models.BookingQuota) *dto.QuotaResponse {
+	return &dto.QuotaResponse{
+		ID:                quota.ID,
+		Scope:             quota.Scope(),
+		UserID:            quota.UserID,
+		Role:              string(quota.Role),
+		Category:          quota.Category,
+		MaxActiveBookings: quota.MaxActiveBookings,
+		MaxHoursPerDay:    quota.MaxHoursPerDay,
+		MaxHoursPerWeek:   quota.MaxHoursPerWeek,
+		MaxHoursPerMonth:  quota.MaxHoursPerMonth,
+	}
+}
@@ -6,14 +6,20 @@ import (
 	"Reservify/repositories"
 	"Reservify/utils"
 	"errors"
+	"fmt"
+	"time"
 )
 
 type ResourceService struct {
 	resourceRepo *repositories.ResourceRepository
+	userRepo     *repositories.UserRepository
 }
 
-func NewResourceService(resourceRepo *repositories.ResourceRepository) *ResourceService {
-	return &ResourceService{resourceRepo: resourceRepo}
+func NewResourceService(resourceRepo *repositories.ResourceRepository, userRepo *repositories.UserRepository) *ResourceService {
+	return &ResourceService{
+		resourceRepo: resourceRepo,
+		userRepo:     userRepo,
+	}
 }
 
 // GetAllResources obtiene todos los recursos con paginación
@@ -33,6 +39,8 @@ func (s *ResourceService) GetAllResources(params utils.PaginationParams, activeO
 			Category:     resource.Category,
 			ImageURL:     resource.ImageURL,
 			IsActive:     resource.IsActive,
+			BookingMode:  string(resource.BookingMode),
+			Timezone:     resource.Timezone,
 		})
 	}
 
@@ -56,6 +64,8 @@ func (s *ResourceService) GetResourcesByCategory(category string, params utils.P
 			Category:     resource.Category,
 			ImageURL:     resource.ImageURL,
 			IsActive:     resource.IsActive,
+			BookingMode:  string(resource.BookingMode),
+			Timezone:     resource.Timezone,
 		})
 	}
 
@@ -70,16 +80,26 @@ func (s *ResourceService) GetResourceByID(id uint) (*dto.ResourceResponse, error
 	}
 
 	response := &dto.ResourceResponse{
-		ID:           resource.ID,
-		Name:         resource.Name,
-		Description:  resource.Description,
-		Capacity:     resource.Capacity,
-		PricePerHour: resource.PricePerHour,
-		Category:     resource.Category,
-		ImageURL:     resource.ImageURL,
-		IsActive:     resource.IsActive,
-		CreatedAt:    resource.CreatedAt,
-		UpdatedAt:    resource.UpdatedAt,
+		ID:              resource.ID,
+		Name:            resource.Name,
+		Description:     resource.Description,
+		Capacity:        resource.Capacity,
+		PricePerHour:    resource.PricePerHour,
+		Category:        resource.Category,
+		ImageURL:        resource.ImageURL,
+		IsActive:        resource.IsActive,
+		BookingMode:     string(resource.BookingMode),
+		PricePerSeat:    resource.PricePerSeat,
+		Timezone:        resource.Timezone,
+		BufferBefore:    resource.BufferBefore,
+		BufferAfter:     resource.BufferAfter,
+		HoldTTL:         resource.HoldTTL,
+		RequiresCheckIn: resource.RequiresCheckIn,
+		CheckInBefore:   resource.CheckInBefore,
+		CheckInGrace:    resource.CheckInGrace,
+		ApprovalMode:    string(resource.ApprovalMode),
+		CreatedAt:       resource.CreatedAt,
+		UpdatedAt:       resource.UpdatedAt,
 	}
 
 	return response, nil
@@ -87,14 +107,47 @@ func (s *ResourceService) GetResourceByID(id uint) (*dto.ResourceResponse, error
 
 // CreateResource crea un nuevo recurso
 func (s *ResourceService) CreateResource(req *dto.CreateResourceRequest) (*dto.ResourceResponse, error) {
+	timezone, err := validateTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingMode := models.BookingModeExclusive
+	if req.BookingMode != "" {
+		bookingMode = models.BookingMode(req.BookingMode)
+	}
+
+	checkInBefore, checkInGrace := DefaultCheckInMinutes, DefaultCheckInMinutes
+	if req.CheckInBefore != nil {
+		checkInBefore = *req.CheckInBefore
+	}
+	if req.CheckInGrace != nil {
+		checkInGrace = *req.CheckInGrace
+	}
+
+	approvalMode := models.ApprovalAdmin
+	if req.ApprovalMode != "" {
+		approvalMode = models.ApprovalMode(req.ApprovalMode)
+	}
+
 	resource := &models.Resource{
-		Name:         req.Name,
-		Description:  req.Description,
-		Capacity:     req.Capacity,
-		PricePerHour: req.PricePerHour,
-		Category:     req.Category,
-		ImageURL:     req.ImageURL,
-		IsActive:     true,
+		Name:            req.Name,
+		Description:     req.Description,
+		Capacity:        req.Capacity,
+		PricePerHour:    req.PricePerHour,
+		Category:        req.Category,
+		ImageURL:        req.ImageURL,
+		IsActive:        true,
+		BookingMode:     bookingMode,
+		PricePerSeat:    req.PricePerSeat,
+		Timezone:        timezone,
+		BufferBefore:    req.BufferBefore,
+		BufferAfter:     req.BufferAfter,
+		HoldTTL:         req.HoldTTL,
+		RequiresCheckIn: req.RequiresCheckIn,
+		CheckInBefore:   checkInBefore,
+		CheckInGrace:    checkInGrace,
+		ApprovalMode:    approvalMode,
 	}
 
 	if err := s.resourceRepo.Create(resource); err != nil {
@@ -133,6 +186,40 @@ func (s *ResourceService) UpdateResource(id uint, req *dto.UpdateResourceRequest
 	if req.IsActive != nil {
 		resource.IsActive = *req.IsActive
 	}
+	if req.BookingMode != "" {
+		resource.BookingMode = models.BookingMode(req.BookingMode)
+	}
+	if req.PricePerSeat != nil {
+		resource.PricePerSeat = *req.PricePerSeat
+	}
+	if req.Timezone != "" {
+		timezone, err := validateTimezone(req.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		resource.Timezone = timezone
+	}
+	if req.BufferBefore != nil {
+		resource.BufferBefore = *req.BufferBefore
+	}
+	if req.BufferAfter != nil {
+		resource.BufferAfter = *req.BufferAfter
+	}
+	if req.HoldTTL != nil {
+		resource.HoldTTL = *req.HoldTTL
+	}
+	if req.RequiresCheckIn != nil {
+		resource.RequiresCheckIn = *req.RequiresCheckIn
+	}
+	if req.CheckInBefore != nil {
+		resource.CheckInBefore = *req.CheckInBefore
+	}
+	if req.CheckInGrace != nil {
+		resource.CheckInGrace = *req.CheckInGrace
+	}
+	if req.ApprovalMode != "" {
+		resource.ApprovalMode = models.ApprovalMode(req.ApprovalMode)
+	}
 
 	if err := s.resourceRepo.Update(resource); err != nil {
 		return nil, errors.New("error al actualizar el recurso")
@@ -141,6 +228,62 @@ func (s *ResourceService) UpdateResource(id uint, req *dto.UpdateResourceRequest
 	return s.GetResourceByID(resource.ID)
 }
 
+// GetApprovers obtiene los aprobadores designados de un recurso
+func (s *ResourceService) GetApprovers(id uint) ([]dto.UserResponse, error) {
+	resource, err := s.resourceRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers, err := s.resourceRepo.FindApprovers(resource)
+	if err != nil {
+		return nil, err
+	}
+	return mapApprovers(approvers), nil
+}
+
+// SetApprovers reemplaza los aprobadores designados de un recurso.
+// Solo intervienen cuando el recurso usa el modo de aprobación "approvers".
+func (s *ResourceService) SetApprovers(id uint, req *dto.ResourceApproversRequest) ([]dto.UserResponse, error) {
+	resource, err := s.resourceRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := make([]models.User, 0, len(req.UserIDs))
+	seen := make(map[uint]bool, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		user, err := s.userRepo.FindByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("usuario %d: %w", userID, err)
+		}
+		approvers = append(approvers, *user)
+	}
+
+	if err := s.resourceRepo.ReplaceApprovers(resource, approvers); err != nil {
+		return nil, errors.New("error al guardar los aprobadores")
+	}
+
+	return mapApprovers(approvers), nil
+}
+
+// UpdateCategoryBuffers aplica los mismos márgenes a todos los recursos de una categoría
+func (s *ResourceService) UpdateCategoryBuffers(category string, req *dto.CategoryBuffersRequest) (int64, error) {
+	updated, err := s.resourceRepo.UpdateBuffersByCategory(category, *req.BufferBefore, *req.BufferAfter)
+	if err != nil {
+		return 0, errors.New("error al actualizar los márgenes de la categoría")
+	}
+	if updated == 0 {
+		return 0, errors.New("no hay recursos en esa categoría")
+	}
+	return updated, nil
+}
+
 // DeleteResource elimina un recurso
 func (s *ResourceService) DeleteResource(id uint) error {
 	return s.resourceRepo.Delete(id)
@@ -170,3 +313,30 @@ func (s *ResourceService) GetResourceStats() (map[string]interface{}, error) {
 
 	return stats, nil
 }
+
+// mapApprovers convierte los aprobadores a DTOs
+func mapApprovers(users []models.User) []dto.UserResponse {
+	response := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, dto.UserResponse{
+			ID:        user.ID,
+			Email:     user.Email,
+			FullName:  user.FullName,
+			Phone:     user.Phone,
+			Role:      string(user.Role),
+			CreatedAt: user.CreatedAt,
+		})
+	}
+	return response
+}
+
+// validateTimezone verifica que la zona horaria sea un nombre IANA válido
+func validateTimezone(timezone string) (string, error) {
+	if timezone == "" {
+		return "UTC", nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return "", errors.New("zona horaria inválida")
+	}
+	return timezone, nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"Reservify/utils"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// resourceSchedule reúne todo lo que define cuándo se puede reservar un recurso
+type resourceSchedule struct {
+	loc       *time.Location
+	slots     []models.AvailabilitySlot
+	overrides map[string][]models.AvailabilityOverride // Por fecha AAAA-MM-DD
+	blackouts []closure
+}
+
+// closure representa un tramo cerrado con su motivo
+type closure struct {
+	utils.TimeRange
+	reason string
+}
+
+// newResourceSchedule arma el calendario de un recurso a partir de datos ya cargados
+func newResourceSchedule(
+	resource *models.Resource,
+	slots []models.AvailabilitySlot,
+	overrides []models.AvailabilityOverride,
+	blackouts []models.Blackout,
+) *resourceSchedule {
+	schedule := &resourceSchedule{
+		loc:       resource.Location(),
+		slots:     slots,
+		overrides: make(map[string][]models.AvailabilityOverride),
+	}
+	for _, override := range overrides {
+		if override.ResourceID == resource.ID {
+			schedule.overrides[override.Date] = append(schedule.overrides[override.Date], override)
+		}
+	}
+	for i := range blackouts {
+		if !blackouts[i].AppliesTo(resource) {
+			continue
+		}
+		if r, ok := blackoutRange(&blackouts[i], schedule.loc); ok {
+			schedule.blackouts = append(schedule.blackouts, closure{TimeRange: r, reason: blackouts[i].Reason})
+		}
+	}
+	return schedule
+}
+
+// loadSchedule carga horarios, excepciones y cierres de un recurso para el rango indicado
+func loadSchedule(
+	availabilityRepo *repositories.AvailabilityRepository,
+	blackoutRepo *repositories.BlackoutRepository,
+	resource *models.Resource,
+	from, to time.Time,
+) (*resourceSchedule, error) {
+	slots, err := availabilityRepo.FindByResourceID(resource.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate, toDate := overrideDateRange(from, to)
+	overrides, err := availabilityRepo.FindOverrides(resource.ID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := blackoutRepo.FindInRange([]models.Resource{*resource}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResourceSchedule(resource, slots, overrides, blackouts), nil
+}
+
+// overrideDateRange devuelve las fechas a consultar con un día de margen, porque cada recurso tiene su zona horaria
+func overrideDateRange(from, to time.Time) (string, string) {
+	return from.UTC().AddDate(0, 0, -1).Format("2006-01-02"), to.UTC().AddDate(0, 0, 1).Format("2006-01-02")
+}
+
+// openRanges devuelve los tramos abiertos entre from y to (horario efectivo menos cierres)
+func (sc *resourceSchedule) openRanges(from, to time.Time) []utils.TimeRange {
+	closed := make([]utils.TimeRange, 0, len(sc.blackouts))
+	for _, b := range sc.blackouts {
+		closed = append(closed, b.TimeRange)
+	}
+
+	var open []utils.TimeRange
+	for _, opening := range sc.openingHours(from, to) {
+		open = append(open, utils.SubtractRanges(opening, closed)...)
+	}
+	return open
+}
+
+// effectiveDay describe el horario real de un día local: origen, franjas abiertas y cierres que lo afectan
+func (sc *resourceSchedule) effectiveDay(day time.Time) dto.EffectiveDay {
+	source := "weekly"
+	if _, ok := sc.overrides[day.Format("2006-01-02")]; ok {
+		source = "override"
+	}
+
+	result := dto.EffectiveDay{
+		Date:      day.Format("2006-01-02"),
+		DayOfWeek: string(models.DayOfWeekFromWeekday(day.Weekday())),
+		Source:    source,
+		Windows:   []dto.ScheduleWindow{},
+	}
+
+	hours := utils.MergeRanges(sc.dayHours(day))
+	var closed []utils.TimeRange
+	for _, b := range sc.blackouts {
+		for _, opening := range hours {
+			if b.Start.Before(opening.End) && b.End.After(opening.Start) {
+				closed = append(closed, b.TimeRange)
+				result.Closures = append(result.Closures, b.reason)
+				break
+			}
+		}
+	}
+
+	for _, opening := range hours {
+		for _, open := range utils.SubtractRanges(opening, closed) {
+			result.Windows = append(result.Windows, dto.ScheduleWindow{Start: open.Start.In(sc.loc), End: open.End.In(sc.loc)})
+		}
+	}
+	return result
+}
+
+// check verifica que todo el intervalo esté abierto e indica qué parte no lo está
+func (sc *resourceSchedule) check(start, end time.Time) error {
+	target := utils.TimeRange{Start: start.In(sc.loc), End: end.In(sc.loc)}
+
+	// Los cierres tienen prioridad para poder informar el motivo
+	for _, b := range sc.blackouts {
+		if b.Start.Before(target.End) && b.End.After(target.Start) {
+			return fmt.Errorf("el recurso está cerrado (%s): %s", b.reason,
+				utils.FormatRanges([]utils.TimeRange{{Start: b.Start.In(sc.loc), End: b.End.In(sc.loc)}}))
+		}
+	}
+
+	if len(sc.slots) == 0 && len(sc.overrides) == 0 {
+		return errors.New("el recurso no tiene horarios de disponibilidad configurados")
+	}
+
+	// Los horarios se interpretan en la zona horaria del recurso
+	gaps := utils.SubtractRanges(target, sc.openingHours(start, end))
+	if len(gaps) > 0 {
+		return fmt.Errorf("el horario solicitado está fuera del horario de atención: %s", utils.FormatRanges(gaps))
+	}
+
+	return nil
+}
+
+// blackoutRange convierte un cierre en un intervalo concreto en la zona del recurso
+func blackoutRange(blackout *models.Blackout, loc *time.Location) (utils.TimeRange, bool) {
+	if !blackout.AllDay {
+		if blackout.StartDatetime == nil || blackout.EndDatetime == nil {
+			return utils.TimeRange{}, false
+		}
+		return utils.TimeRange{Start: *blackout.StartDatetime, End: *blackout.EndDatetime}, true
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", blackout.StartDate, loc)
+	if err != nil {
+		return utils.TimeRange{}, false
+	}
+	endDate, err := time.ParseInLocation("2006-01-02", blackout.EndDate, loc)
+	if err != nil {
+		return utils.TimeRange{}, false
+	}
+
+	// EndDate es inclusiva: el cierre termina a la medianoche siguiente
+	return utils.TimeRange{Start: startDate, End: endDate.AddDate(0, 0, 1)}, true
+}
+
+// openingHours convierte el horario efectivo en intervalos concretos entre from y to.
+// Las fechas con excepciones usan sus franjas en lugar de la plantilla semanal.
+func (sc *resourceSchedule) openingHours(from, to time.Time) []utils.TimeRange {
+	from = from.In(sc.loc)
+	to = to.In(sc.loc)
+
+	var ranges []utils.TimeRange
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, sc.loc)
+	for day.Before(to) {
+		ranges = append(ranges, sc.dayHours(day)...)
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return utils.MergeRanges(ranges)
+}
+
+// dayHours devuelve las franjas de apertura de un día (medianoche local)
+func (sc *resourceSchedule) dayHours(day time.Time) []utils.TimeRange {
+	var ranges []utils.TimeRange
+	add := func(startTime, endTime string) {
+		start, err := utils.ClockOn(day, startTime)
+		if err != nil {
+			return
+		}
+		end, err := utils.ClockOn(day, endTime)
+		if err != nil || !start.Before(end) {
+			return
+		}
+		ranges = append(ranges, utils.TimeRange{Start: start, End: end})
+	}
+
+	if overrides, ok := sc.overrides[day.Format("2006-01-02")]; ok {
+		for _, override := range overrides {
+			add(override.StartTime, override.EndTime)
+		}
+		return ranges
+	}
+
+	dayOfWeek := models.DayOfWeekFromWeekday(day.Weekday())
+	for _, slot := range sc.slots {
+		if slot.DayOfWeek == dayOfWeek {
+			add(slot.StartTime, slot.EndTime)
+		}
+	}
+	return ranges
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type WaitlistService struct {
+	waitlistRepo *repositories.WaitlistRepository
+	resourceRepo *repositories.ResourceRepository
+	bookingRepo  *repositories.BookingRepository
+}
+
+func NewWaitlistService(
+	waitlistRepo *repositories.WaitlistRepository,
+	resourceRepo *repositories.ResourceRepository,
+	bookingRepo *repositories.BookingRepository,
+) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo: waitlistRepo,
+		resourceRepo: resourceRepo,
+		bookingRepo:  bookingRepo,
+	}
+}
+
+// JoinWaitlist apunta al usuario a la lista de espera de un horario que ahora está ocupado
+func (s *WaitlistService) JoinWaitlist(userID uint, req *dto.JoinWaitlistRequest) (*dto.WaitlistEntryResponse, error) {
+	// Validar fechas
+	if !req.StartDatetime.Before(req.EndDatetime) {
+		return nil, errors.New("la fecha de inicio debe ser anterior a la fecha de fin")
+	}
+
+	if req.StartDatetime.Before(time.Now()) {
+		return nil, errors.New("no se puede esperar un horario en el pasado")
+	}
+
+	resource, err := s.resourceRepo.FindByID(req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resource.IsActive {
+		return nil, errors.New("el recurso no está disponible")
+	}
+
+	seats, err := resolveSeats(resource, req.Seats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo tiene sentido esperar si el horario está ocupado
+	start, end := req.StartDatetime.UTC(), req.EndDatetime.UTC()
+	if err := checkConflict(s.bookingRepo, resource, start, end, seats, nil); err == nil {
+		return nil, errors.New("el horario está libre, puedes reservarlo directamente")
+	} else if !errors.Is(err, ErrBookingConflict) {
+		return nil, err
+	}
+
+	waiting, err := s.waitlistRepo.FindWaitingOverlapping(resource.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range waiting {
+		if entry.UserID == userID {
+			return nil, errors.New("ya estás en la lista de espera de ese horario")
+		}
+	}
+
+	position, err := s.waitlistRepo.NextPosition(resource.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.WaitlistEntry{
+		UserID:        userID,
+		ResourceID:    resource.ID,
+		StartDatetime: start,
+		EndDatetime:   end,
+		Seats:         seats,
+		Notes:         req.Notes,
+		Position:      position,
+		Status:        models.WaitlistWaiting,
+	}
+
+	if err := s.waitlistRepo.Create(entry); err != nil {
+		return nil, errors.New("error al unirse a la lista de espera")
+	}
+
+	entry.Resource = *resource
+	return mapWaitlistEntry(entry), nil
+}
+
+// GetMyWaitlist obtiene las entradas de lista de espera del usuario
+func (s *WaitlistService) GetMyWaitlist(userID uint) ([]dto.WaitlistEntryResponse, error) {
+	entries, err := s.waitlistRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapWaitlistEntries(entries), nil
+}
+
+// LeaveWaitlist saca una entrada de la lista de espera
+func (s *WaitlistService) LeaveWaitlist(id uint, userID uint, isAdmin bool) error {
+	entry, err := s.waitlistRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	// Verificar permisos
+	if !isAdmin && entry.UserID != userID {
+		return errors.New("no tienes permisos para modificar esta entrada")
+	}
+
+	left, err := s.waitlistRepo.TransitionStatus(entry.ID, models.WaitlistWaiting, models.WaitlistCancelled)
+	if err != nil {
+		return errors.New("error al salir de la lista de espera")
+	}
+	if !left {
+		return errors.New("la entrada ya no está en espera")
+	}
+	return nil
+}
+
+// GetResourceWaitlist obtiene la lista de espera de un recurso en orden de prioridad (admin)
+func (s *WaitlistService) GetResourceWaitlist(resourceID uint) ([]dto.WaitlistEntryResponse, error) {
+	if _, err := s.resourceRepo.FindByID(resourceID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.waitlistRepo.FindWaitingByResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return mapWaitlistEntries(entries), nil
+}
+
+// ReorderWaitlist reasigna las posiciones de la lista de espera de un recurso (admin).
+// entry_ids debe contener exactamente las entradas en espera del recurso.
+func (s *WaitlistService) ReorderWaitlist(resourceID uint, req *dto.ReorderWaitlistRequest) ([]dto.WaitlistEntryResponse, error) {
+	if _, err := s.resourceRepo.FindByID(resourceID); err != nil {
+		return nil, err
+	}
+
+	err := s.waitlistRepo.WithTransaction(func(txRepo *repositories.WaitlistRepository) error {
+		entries, err := txRepo.FindWaitingByResource(resourceID)
+		if err != nil {
+			return err
+		}
+
+		waiting := make(map[uint]bool, len(entries))
+		for _, entry := range entries {
+			waiting[entry.ID] = true
+		}
+
+		if len(req.EntryIDs) != len(entries) {
+			return fmt.Errorf("se esperaban %d entradas en espera y se recibieron %d", len(entries), len(req.EntryIDs))
+		}
+
+		for i, id := range req.EntryIDs {
+			if !waiting[id] {
+				return fmt.Errorf("la entrada %d no está en espera para este recurso o está repetida", id)
+			}
+			delete(waiting, id)
+
+			if err := txRepo.UpdatePosition(id, i+1); err != nil {
+				return errors.New("error al reordenar la lista de espera")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetResourceWaitlist(resourceID)
+}
+
+// promoteWaitlist ofrece un horario liberado a las entradas en espera que lo solapan, por orden.
+// Cada entrada se reserva como pendiente si sigue cumpliendo todas las reglas y se notifica al
+// usuario; las que ya empezaron expiran. Los fallos no afectan a la operación que liberó el horario.
+func (s *BookingService) promoteWaitlist(resource *models.Resource, start, end time.Time) {
+	if !resource.IsActive {
+		return
+	}
+
+	// Los márgenes hacen que una entrada contigua también pueda beneficiarse del hueco
+	gap := resource.TurnoverGap()
+	entries, err := s.waitlistRepo.FindWaitingOverlapping(resource.ID, start.Add(-gap), end.Add(gap))
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for i := range entries {
+		entry := &entries[i]
+
+		if !entry.StartDatetime.After(now) {
+			_, _ = s.waitlistRepo.TransitionStatus(entry.ID, models.WaitlistWaiting, models.WaitlistExpired)
+			continue
+		}
+
+		// Reclamar la entrada para que otra cancelación simultánea no la promocione dos veces
+		claimed, err := s.waitlistRepo.TransitionStatus(entry.ID, models.WaitlistWaiting, models.WaitlistPromoted)
+		if err != nil || !claimed {
+			continue
+		}
+
+		booking := &models.Booking{
+			UserID:        entry.UserID,
+			StartDatetime: entry.StartDatetime,
+			EndDatetime:   entry.EndDatetime,
+			Seats:         entry.Seats,
+			Notes:         entry.Notes,
+		}
+		if err := s.book(booking, resource, nil, reasonWaitlistPromoted, false); err != nil {
+			// Sigue sin caber (o ya no cumple política o cuotas): vuelve a esperar
+			_, _ = s.waitlistRepo.TransitionStatus(entry.ID, models.WaitlistPromoted, models.WaitlistWaiting)
+			continue
+		}
+
+		_ = s.waitlistRepo.SetBooking(entry.ID, booking.ID)
+
+		_ = s.notificationRepo.Create(&models.Notification{
+			UserID:    entry.UserID,
+			BookingID: &booking.ID,
+			Message: fmt.Sprintf("Se liberó el horario que esperabas en %s: tu reserva #%d quedó pendiente de confirmación",
+				resource.Name, booking.ID),
+		})
+	}
+}
+
+// mapWaitlistEntries convierte entradas de lista de espera a DTOs
+func mapWaitlistEntries(entries []models.WaitlistEntry) []dto.WaitlistEntryResponse {
+	response := make([]dto.WaitlistEntryResponse, 0, len(entries))
+	for i := range entries {
+		response = append(response, *mapWaitlistEntry(&entries[i]))
+	}
+	return response
+}
+
+// mapWaitlistEntry convierte una entrada de lista de espera a DTO
+func mapWaitlistEntry(entry *models.WaitlistEntry) *dto.WaitlistEntryResponse {
+	return &dto.WaitlistEntryResponse{
+		ID:            entry.ID,
+		UserID:        entry.UserID,
+		UserName:      entry.User.FullName,
+		ResourceID:    entry.ResourceID,
+		ResourceName:  entry.Resource.Name,
+		StartDatetime: entry.StartDatetime,
+		EndDatetime:   entry.EndDatetime,
+		Seats:         entry.Seats,
+		Notes:         entry.Notes,
+		Position:      entry.Position,
+		Status:        string(entry.Status),
+		BookingID:     entry.BookingID,
+		CreatedAt:     entry.CreatedAt,
+	}
+}
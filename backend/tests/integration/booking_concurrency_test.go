@@ -0,0 +1,114 @@
+package integration_test
+
+import (
+	"Reservify/dto"
+	"Reservify/models"
+	"Reservify/repositories"
+	"Reservify/services"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Estas pruebas necesitan una base de datos MySQL real, por ejemplo:
+// TEST_DB_DSN="root:@tcp(localhost:3306)/reservify_test?charset=utf8mb4&parseTime=True&loc=UTC"
+func openTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN no configurado, se omiten las pruebas de integración")
+	}
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, models.AutoMigrate(db))
+	return db
+}
+
+func TestCreateBookingConcurrency(t *testing.T) {
+	db := openTestDB(t)
+
+	user := &models.User{
+		Email:        fmt.Sprintf("concurrency-%d@test.com", time.Now().UnixNano()),
+		PasswordHash: "hash",
+		FullName:     "Usuario Concurrencia",
+		Role:         models.RoleUser,
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	resource := &models.Resource{Name: "Sala Concurrencia", Capacity: 10, PricePerHour: 10, IsActive: true}
+	require.NoError(t, db.Create(resource).Error)
+
+	// Abierto todo el día para aislar la prueba de los horarios
+	for _, day := range []models.DayOfWeek{models.Monday, models.Tuesday, models.Wednesday, models.Thursday, models.Friday, models.Saturday, models.Sunday} {
+		slot := &models.AvailabilitySlot{ResourceID: resource.ID, DayOfWeek: day, StartTime: "00:00:00", EndTime: "24:00:00"}
+		require.NoError(t, db.Create(slot).Error)
+	}
+
+	t.Cleanup(func() {
+		db.Unscoped().Where("resource_id = ?", resource.ID).Delete(&models.Booking{})
+		db.Unscoped().Where("resource_id = ?", resource.ID).Delete(&models.AvailabilitySlot{})
+		db.Unscoped().Delete(resource)
+		db.Unscoped().Delete(user)
+	})
+
+	service := services.NewBookingService(
+		repositories.NewBookingRepository(db),
+		repositories.NewResourceRepository(db),
+		repositories.NewUserRepository(db),
+		repositories.NewAvailabilityRepository(db),
+		repositories.NewBlackoutRepository(db),
+		repositories.NewBookingPolicyRepository(db),
+		repositories.NewQuotaRepository(db),
+		repositories.NewWaitlistRepository(db),
+		repositories.NewNotificationRepository(db),
+	)
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	req := dto.CreateBookingRequest{
+		ResourceID:    resource.ID,
+		StartDatetime: start,
+		EndDatetime:   start.Add(time.Hour),
+	}
+
+	const attempts = 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	successes, conflicts := 0, 0
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := req
+			_, err := service.CreateBooking(user.ID, &r)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				successes++
+			case errors.Is(err, services.ErrBookingConflict):
+				conflicts++
+			default:
+				t.Errorf("error inesperado: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, successes, "Solo una reserva debería ganar")
+	assert.Equal(t, attempts-1, conflicts, "El resto debería recibir conflicto")
+
+	var count int64
+	db.Model(&models.Booking{}).Where("resource_id = ?", resource.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
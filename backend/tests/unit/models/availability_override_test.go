@@ -0,0 +1,13 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailabilityOverrideTableName(t *testing.T) {
+	override := models.AvailabilityOverride{}
+	assert.Equal(t, "availability_overrides", override.TableName(), "El nombre de la tabla debería ser 'availability_overrides'")
+}
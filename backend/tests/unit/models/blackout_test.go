@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlackoutTableName(t *testing.T) {
+	blackout := models.Blackout{}
+	assert.Equal(t, "blackouts", blackout.TableName(), "El nombre de la tabla debería ser 'blackouts'")
+}
+
+func TestBlackoutAppliesTo(t *testing.T) {
+	room := &models.Resource{ID: 1, Category: "Salas"}
+	court := &models.Resource{ID: 2, Category: "Canchas"}
+	resourceID := uint(1)
+
+	tests := []struct {
+		name     string
+		blackout models.Blackout
+		room     bool
+		court    bool
+	}{
+		{"Por recurso", models.Blackout{ResourceID: &resourceID}, true, false},
+		{"Por categoría", models.Blackout{Category: "Canchas"}, false, true},
+		{"Global", models.Blackout{}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.room, tt.blackout.AppliesTo(room))
+			assert.Equal(t, tt.court, tt.blackout.AppliesTo(court))
+		})
+	}
+}
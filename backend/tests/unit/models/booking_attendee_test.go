@@ -0,0 +1,46 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingAttendeeTableName(t *testing.T) {
+	attendee := models.BookingAttendee{}
+	assert.Equal(t, "booking_attendees", attendee.TableName(), "El nombre de la tabla debería ser 'booking_attendees'")
+}
+
+func TestBookingAttendeeIsExternal(t *testing.T) {
+	userID := uint(3)
+
+	assert.False(t, (&models.BookingAttendee{UserID: &userID}).IsExternal(), "Un usuario registrado no es externo")
+	assert.True(t, (&models.BookingAttendee{Email: "invitado@example.com"}).IsExternal(), "Un invitado por email es externo")
+}
+
+func TestBookingAttendeeRespond(t *testing.T) {
+	attendee := models.BookingAttendee{RSVPStatus: models.RSVPPending}
+	now := time.Date(2025, 3, 10, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	attendee.Respond(models.RSVPAccepted, now)
+
+	assert.Equal(t, models.RSVPAccepted, attendee.RSVPStatus)
+	if assert.NotNil(t, attendee.RespondedAt) {
+		assert.Equal(t, time.UTC, attendee.RespondedAt.Location(), "La respuesta debería guardarse en UTC")
+		assert.True(t, now.Equal(*attendee.RespondedAt))
+	}
+}
+
+func TestHeadcount(t *testing.T) {
+	attendees := []models.BookingAttendee{
+		{RSVPStatus: models.RSVPPending},
+		{RSVPStatus: models.RSVPAccepted},
+		{RSVPStatus: models.RSVPTentative},
+		{RSVPStatus: models.RSVPDeclined},
+	}
+
+	assert.Equal(t, 1, models.Headcount(nil), "Sin invitados solo cuenta el titular")
+	assert.Equal(t, 4, models.Headcount(attendees), "Los invitados que rechazaron no deberían contar")
+}
@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingEventTableName(t *testing.T) {
+	event := models.BookingEvent{}
+	assert.Equal(t, "booking_events", event.TableName(), "El nombre de la tabla debería ser 'booking_events'")
+}
+
+func TestDiffBooking(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	before := models.Booking{
+		ID:            1,
+		UserID:        5,
+		Status:        models.StatusPending,
+		StartDatetime: start,
+		EndDatetime:   start.Add(time.Hour),
+		Seats:         1,
+		TotalPrice:    20,
+		Notes:         "Reunión",
+	}
+
+	after := before
+	after.EndDatetime = start.Add(2 * time.Hour)
+	after.TotalPrice = 40
+
+	changes := models.DiffBooking(&before, &after)
+	assert.Equal(t, models.FieldChanges{
+		{Field: "end_datetime", From: "2025-03-10T10:00:00Z", To: "2025-03-10T11:00:00Z"},
+		{Field: "total_price", From: "20.00", To: "40.00"},
+	}, changes)
+
+	assert.Empty(t, models.DiffBooking(&before, &before), "Sin cambios no debería haber diferencias")
+}
+
+func TestNewBookingEvent(t *testing.T) {
+	actorID := uint(7)
+	booking := models.Booking{
+		ID:            3,
+		UserID:        5,
+		Status:        models.StatusPending,
+		StartDatetime: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		EndDatetime:   time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC),
+		Seats:         1,
+	}
+
+	created := models.NewBookingEvent(nil, &booking, &actorID, "")
+	if assert.NotNil(t, created) {
+		assert.Equal(t, models.EventCreated, created.Type)
+		assert.Equal(t, uint(3), created.BookingID)
+		assert.Equal(t, &actorID, created.ActorID)
+	}
+
+	cancelled := booking
+	cancelled.Status = models.StatusCancelled
+	statusChanged := models.NewBookingEvent(&booking, &cancelled, nil, "cierre del recurso")
+	if assert.NotNil(t, statusChanged) {
+		assert.Equal(t, models.EventStatusChanged, statusChanged.Type)
+		assert.Nil(t, statusChanged.ActorID, "Los cambios del sistema no tienen actor")
+		assert.Equal(t, "cierre del recurso", statusChanged.Reason)
+		assert.Equal(t, models.FieldChanges{{Field: "status", From: "pending", To: "cancelled"}}, statusChanged.Changes)
+	}
+
+	edited := booking
+	edited.Notes = "Traer proyector"
+	modified := models.NewBookingEvent(&booking, &edited, &actorID, "")
+	if assert.NotNil(t, modified) {
+		assert.Equal(t, models.EventModified, modified.Type)
+	}
+
+	assert.Nil(t, models.NewBookingEvent(&booking, &booking, &actorID, ""), "Sin cambios no se registra evento")
+}
+
+func TestFieldChangesJSON(t *testing.T) {
+	changes := models.FieldChanges{{Field: "status", From: "pending", To: "confirmed"}}
+
+	value, err := changes.Value()
+	assert.NoError(t, err)
+
+	var scanned models.FieldChanges
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, changes, scanned)
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+	assert.Error(t, scanned.Scan(42))
+}
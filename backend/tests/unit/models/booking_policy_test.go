@@ -0,0 +1,67 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestBookingPolicyScope(t *testing.T) {
+	resourceID := uint(3)
+
+	assert.Equal(t, "resource", (&models.BookingPolicy{ResourceID: &resourceID}).Scope())
+	assert.Equal(t, "category", (&models.BookingPolicy{Category: "Salas"}).Scope())
+	assert.Equal(t, "global", (&models.BookingPolicy{}).Scope())
+}
+
+func TestBookingPolicyInherit(t *testing.T) {
+	global := models.BookingPolicy{MaxDaysAhead: intPtr(60), MinNoticeMinutes: intPtr(30)}
+	category := models.BookingPolicy{Category: "Salas", MaxDurationMinutes: intPtr(240), MaxDaysAhead: intPtr(14)}
+	resource := models.BookingPolicy{MaxDurationMinutes: intPtr(120)}
+
+	effective := resource.Inherit(category).Inherit(global)
+
+	assert.Equal(t, 120, *effective.MaxDurationMinutes, "El recurso manda sobre la categoría")
+	assert.Equal(t, 14, *effective.MaxDaysAhead, "La categoría manda sobre la global")
+	assert.Equal(t, 30, *effective.MinNoticeMinutes, "Se hereda de la global")
+	assert.Nil(t, effective.StartGranularityMinutes, "Sin regla en ningún nivel")
+}
+
+func TestFeeTiersFeePercent(t *testing.T) {
+	// Gratis hasta 48 h antes, 50 % hasta 24 h, sin reembolso después
+	tiers := models.FeeTiers{{MinutesBefore: 2880, FeePercent: 50}, {MinutesBefore: 1440, FeePercent: 100}}
+
+	assert.Equal(t, 0.0, tiers.FeePercent(72*time.Hour))
+	assert.Equal(t, 0.0, tiers.FeePercent(48*time.Hour), "Justo en el límite sigue siendo gratis")
+	assert.Equal(t, 50.0, tiers.FeePercent(30*time.Hour))
+	assert.Equal(t, 100.0, tiers.FeePercent(2*time.Hour))
+	assert.Equal(t, 100.0, tiers.FeePercent(-time.Hour), "Después del inicio aplica el tramo más estricto")
+	assert.Equal(t, 0.0, models.FeeTiers(nil).FeePercent(time.Hour))
+}
+
+func TestFeeTiersInheritAndStorage(t *testing.T) {
+	global := models.BookingPolicy{CancellationFees: models.FeeTiers{{MinutesBefore: 1440, FeePercent: 100}}}
+
+	inherited := models.BookingPolicy{}.Inherit(global)
+	assert.Len(t, inherited.CancellationFees, 1, "Sin tramos propios se heredan los del nivel superior")
+
+	free := models.BookingPolicy{CancellationFees: models.FeeTiers{}}.Inherit(global)
+	assert.Empty(t, free.CancellationFees, "Una lista vacía deja la cancelación gratuita")
+	assert.NotNil(t, free.CancellationFees)
+
+	value, err := global.CancellationFees.Value()
+	assert.NoError(t, err)
+
+	var scanned models.FeeTiers
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, global.CancellationFees, scanned)
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
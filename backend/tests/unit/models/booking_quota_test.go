@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestBookingQuotaScope(t *testing.T) {
+	userID := uint(7)
+
+	assert.Equal(t, "user", (&models.BookingQuota{UserID: &userID}).Scope())
+	assert.Equal(t, "role", (&models.BookingQuota{Role: models.RoleUser}).Scope())
+	assert.Equal(t, "global", (&models.BookingQuota{Category: "Salas"}).Scope(), "La categoría no cambia el alcance")
+}
+
+func TestBookingQuotaInherit(t *testing.T) {
+	global := models.BookingQuota{MaxActiveBookings: intPtr(3), MaxHoursPerWeek: floatPtr(10)}
+	user := models.BookingQuota{MaxActiveBookings: intPtr(8)}
+
+	effective := user.Inherit(global)
+
+	assert.Equal(t, 8, *effective.MaxActiveBookings, "El usuario manda sobre la global")
+	assert.Equal(t, 10.0, *effective.MaxHoursPerWeek)
+	assert.Nil(t, effective.MaxHoursPerDay)
+}
@@ -0,0 +1,32 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingTransferTableName(t *testing.T) {
+	transfer := models.BookingTransfer{}
+	assert.Equal(t, "booking_transfers", transfer.TableName(), "El nombre de la tabla debería ser 'booking_transfers'")
+}
+
+func TestBookingTransferIsPending(t *testing.T) {
+	tests := []struct {
+		status   models.TransferStatus
+		expected bool
+	}{
+		{models.TransferPending, true},
+		{models.TransferAccepted, false},
+		{models.TransferDeclined, false},
+		{models.TransferCancelled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			transfer := models.BookingTransfer{Status: tt.status}
+			assert.Equal(t, tt.expected, transfer.IsPending())
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTableNames(t *testing.T) {
+	assert.Equal(t, "job_leases", models.JobLease{}.TableName(), "El nombre de la tabla debería ser 'job_leases'")
+	assert.Equal(t, "job_runs", models.JobRun{}.TableName(), "El nombre de la tabla debería ser 'job_runs'")
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationTableName(t *testing.T) {
+	reservation := models.Reservation{}
+	assert.Equal(t, "reservations", reservation.TableName(), "El nombre de la tabla debería ser 'reservations'")
+}
+
+func TestReservationTotalPrice(t *testing.T) {
+	reservation := models.Reservation{Bookings: []models.Booking{
+		{Status: models.StatusConfirmed, TotalPrice: 50},
+		{Status: models.StatusPending, TotalPrice: 30},
+		{Status: models.StatusCancelled, TotalPrice: 100},
+	}}
+
+	assert.Equal(t, 80.0, reservation.TotalPrice(), "Las reservas canceladas no deberían sumar al precio")
+}
+
+func TestReservationStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []models.BookingStatus
+		expected models.BookingStatus
+	}{
+		{"Todas confirmadas", []models.BookingStatus{models.StatusConfirmed, models.StatusConfirmed}, models.StatusConfirmed},
+		{"Alguna pendiente", []models.BookingStatus{models.StatusConfirmed, models.StatusPending}, models.StatusPending},
+		{"Todas canceladas", []models.BookingStatus{models.StatusCancelled, models.StatusCancelled}, models.StatusCancelled},
+		{"Canceladas y confirmadas", []models.BookingStatus{models.StatusCancelled, models.StatusConfirmed}, models.StatusConfirmed},
+		{"Terminadas", []models.BookingStatus{models.StatusCompleted, models.StatusNoShow}, models.StatusConfirmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation := models.Reservation{}
+			for _, status := range tt.statuses {
+				reservation.Bookings = append(reservation.Bookings, models.Booking{Status: status})
+			}
+			assert.Equal(t, tt.expected, reservation.Status())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package models_test
 import (
 	"Reservify/models"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -29,3 +30,62 @@ func TestResourceModel(t *testing.T) {
 	assert.Equal(t, 50.00, resource.PricePerHour)
 	assert.True(t, resource.IsActive)
 }
+
+func TestResourceLocation(t *testing.T) {
+	resource := models.Resource{Timezone: "America/Bogota"}
+	assert.Equal(t, "America/Bogota", resource.Location().String())
+
+	// Sin zona o con zona inválida se usa UTC
+	assert.Equal(t, "UTC", (&models.Resource{}).Location().String())
+	assert.Equal(t, "UTC", (&models.Resource{Timezone: "Mars/Olympus"}).Location().String())
+}
+
+func TestResourceTurnoverGap(t *testing.T) {
+	resource := models.Resource{BufferBefore: 10, BufferAfter: 15}
+	assert.Equal(t, 25*time.Minute, resource.TurnoverGap(), "Limpieza de la anterior más preparación de la siguiente")
+	assert.Zero(t, (&models.Resource{}).TurnoverGap())
+}
+
+func TestResourcePendingExpiry(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, (&models.Resource{}).PendingExpiry(now), "Sin plazo las reservas pendientes no caducan")
+
+	expiresAt := (&models.Resource{HoldTTL: 30}).PendingExpiry(now)
+	if assert.NotNil(t, expiresAt) {
+		assert.Equal(t, now.Add(30*time.Minute), *expiresAt)
+	}
+
+	auto := models.Resource{HoldTTL: 30, ApprovalMode: models.ApprovalAuto}
+	assert.Nil(t, auto.PendingExpiry(now), "Con confirmación automática no hay reservas pendientes que caduquen")
+}
+
+func TestResourceCheckInWindow(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	resource := models.Resource{CheckInBefore: 10, CheckInGrace: 15}
+
+	assert.Equal(t, start.Add(-10*time.Minute), resource.CheckInOpensAt(start))
+	assert.Equal(t, start.Add(15*time.Minute), resource.CheckInClosesAt(start, end))
+
+	// La gracia nunca se extiende más allá del final de la reserva
+	resource.CheckInGrace = 90
+	assert.Equal(t, end, resource.CheckInClosesAt(start, end))
+}
+
+func TestResourceInitialStatus(t *testing.T) {
+	tests := []struct {
+		mode     models.ApprovalMode
+		expected models.BookingStatus
+	}{
+		{models.ApprovalAuto, models.StatusConfirmed},
+		{models.ApprovalAdmin, models.StatusPending},
+		{models.ApprovalApprovers, models.StatusPending},
+		{"", models.StatusPending},
+	}
+
+	for _, tt := range tests {
+		resource := models.Resource{ApprovalMode: tt.mode}
+		assert.Equal(t, tt.expected, resource.InitialStatus(), "Modo %q", tt.mode)
+	}
+}
@@ -0,0 +1,13 @@
+package models_test
+
+import (
+	"Reservify/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitlistEntryTableName(t *testing.T) {
+	entry := models.WaitlistEntry{}
+	assert.Equal(t, "waitlist_entries", entry.TableName(), "El nombre de la tabla debería ser 'waitlist_entries'")
+}
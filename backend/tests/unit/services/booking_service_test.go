@@ -46,7 +46,7 @@ func TestValidateStatusTransition(t *testing.T) {
 	// Test: Transiciones válidas
 	validTransitions := map[string][]string{
 		"pending":   {"confirmed", "cancelled"},
-		"confirmed": {"completed", "cancelled"},
+		"confirmed": {"completed", "no_show", "cancelled"},
 	}
 
 	for currentStatus, allowedStatuses := range validTransitions {
@@ -64,6 +64,7 @@ func TestValidateStatusTransition(t *testing.T) {
 		"confirmed": {"pending"},
 		"cancelled": {"pending", "confirmed", "completed"},
 		"completed": {"pending", "confirmed", "cancelled"},
+		"no_show":   {"pending", "confirmed", "completed"},
 	}
 
 	for currentStatus, invalidStatuses := range invalidTransitions {
@@ -0,0 +1,22 @@
+package utils_test
+
+import (
+	"Reservify/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInToken(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	token := utils.SignCheckInToken(12, start)
+	assert.NotEmpty(t, token, "El código no debería estar vacío")
+	assert.Equal(t, token, utils.SignCheckInToken(12, start), "El código debería ser estable")
+
+	assert.True(t, utils.VerifyCheckInToken(12, start, token), "El código debería ser válido para su reserva")
+	assert.False(t, utils.VerifyCheckInToken(13, start, token), "El código no debería servir para otra reserva")
+	assert.False(t, utils.VerifyCheckInToken(12, start.Add(time.Hour), token), "El código no debería servir si la reserva se movió")
+	assert.False(t, utils.VerifyCheckInToken(12, start, "codigo-falso"), "Un código inventado no debería ser válido")
+}
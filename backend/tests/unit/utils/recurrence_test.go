@@ -0,0 +1,89 @@
+package utils_test
+
+import (
+	"Reservify/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWeekdays(t *testing.T) {
+	days, err := utils.ParseWeekdays([]string{"mo", "WE", "MO"})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Wednesday}, days, "Debería ignorar duplicados")
+
+	_, err = utils.ParseWeekdays([]string{"XX"})
+	assert.Error(t, err)
+
+	assert.Equal(t, "MO,WE", utils.FormatWeekdays(days))
+}
+
+func TestExpandRecurrence(t *testing.T) {
+	// Martes 7 de enero de 2025 a las 10:00
+	start := time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Semanal cada martes", func(t *testing.T) {
+		result, err := utils.ExpandRecurrence(start, utils.RecurrenceRule{Frequency: utils.FrequencyWeekly, Interval: 1, Count: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			start,
+			time.Date(2025, 1, 14, 10, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 21, 10, 0, 0, 0, time.UTC),
+		}, result)
+	})
+
+	t.Run("Semanal con BYDAY y until", func(t *testing.T) {
+		until := time.Date(2025, 1, 16, 23, 59, 0, 0, time.UTC)
+		rule := utils.RecurrenceRule{
+			Frequency: utils.FrequencyWeekly,
+			Interval:  1,
+			ByDay:     []time.Weekday{time.Thursday, time.Monday},
+			Until:     &until,
+		}
+		result, err := utils.ExpandRecurrence(start, rule)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),  // Jueves
+			time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC), // Lunes
+			time.Date(2025, 1, 16, 10, 0, 0, 0, time.UTC), // Jueves
+		}, result, "El lunes anterior al inicio no debería incluirse")
+	})
+
+	t.Run("Diario cada dos días", func(t *testing.T) {
+		result, err := utils.ExpandRecurrence(start, utils.RecurrenceRule{Frequency: utils.FrequencyDaily, Interval: 2, Count: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 11, 10, 0, 0, 0, time.UTC), result[2])
+	})
+
+	t.Run("Mensual omite meses sin ese día", func(t *testing.T) {
+		monthEnd := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)
+		result, err := utils.ExpandRecurrence(monthEnd, utils.RecurrenceRule{Frequency: utils.FrequencyMonthly, Interval: 1, Count: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			monthEnd,
+			time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 31, 9, 0, 0, 0, time.UTC),
+		}, result)
+	})
+
+	t.Run("Reglas inválidas", func(t *testing.T) {
+		invalid := []utils.RecurrenceRule{
+			{Frequency: "yearly", Interval: 1, Count: 1},
+			{Frequency: utils.FrequencyDaily, Interval: 0, Count: 1},
+			{Frequency: utils.FrequencyDaily, Interval: 1},
+			{Frequency: utils.FrequencyDaily, Interval: 1, Count: utils.MaxOccurrences + 1},
+			{Frequency: utils.FrequencyMonthly, Interval: 1, Count: 2, ByDay: []time.Weekday{time.Monday}},
+		}
+		for _, rule := range invalid {
+			_, err := utils.ExpandRecurrence(start, rule)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Until demasiado lejano", func(t *testing.T) {
+		until := start.AddDate(5, 0, 0)
+		_, err := utils.ExpandRecurrence(start, utils.RecurrenceRule{Frequency: utils.FrequencyDaily, Interval: 1, Until: &until})
+		assert.Error(t, err)
+	})
+}
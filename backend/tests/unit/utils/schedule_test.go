@@ -0,0 +1,176 @@
+package utils_test
+
+import (
+	"Reservify/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, 1, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestClockOn(t *testing.T) {
+	day := at(15, 0, 0)
+
+	t.Run("Formato HH:MM:SS", func(t *testing.T) {
+		result, err := utils.ClockOn(day, "09:30:00")
+		assert.NoError(t, err)
+		assert.Equal(t, at(15, 9, 30), result)
+	})
+
+	t.Run("Formato HH:MM", func(t *testing.T) {
+		result, err := utils.ClockOn(day, "18:00")
+		assert.NoError(t, err)
+		assert.Equal(t, at(15, 18, 0), result)
+	})
+
+	t.Run("24:00:00 es la medianoche siguiente", func(t *testing.T) {
+		result, err := utils.ClockOn(day, "24:00:00")
+		assert.NoError(t, err)
+		assert.Equal(t, at(16, 0, 0), result)
+	})
+
+	t.Run("Formatos inválidos", func(t *testing.T) {
+		for _, value := range []string{"", "9", "ab:cd", "25:00", "24:30", "10:61"} {
+			_, err := utils.ClockOn(day, value)
+			assert.Error(t, err, "Debería fallar con %q", value)
+		}
+	})
+}
+
+func TestMergeRanges(t *testing.T) {
+	ranges := []utils.TimeRange{
+		{Start: at(15, 14, 0), End: at(15, 18, 0)},
+		{Start: at(15, 9, 0), End: at(15, 12, 0)},
+		{Start: at(15, 12, 0), End: at(15, 13, 0)}, // Contiguo
+		{Start: at(15, 15, 0), End: at(15, 16, 0)}, // Contenido
+	}
+
+	merged := utils.MergeRanges(ranges)
+
+	assert.Equal(t, []utils.TimeRange{
+		{Start: at(15, 9, 0), End: at(15, 13, 0)},
+		{Start: at(15, 14, 0), End: at(15, 18, 0)},
+	}, merged)
+	assert.Nil(t, utils.MergeRanges(nil))
+}
+
+func TestSubtractRanges(t *testing.T) {
+	opening := []utils.TimeRange{
+		{Start: at(15, 9, 0), End: at(15, 13, 0)},
+		{Start: at(15, 14, 0), End: at(15, 18, 0)},
+	}
+
+	tests := []struct {
+		name     string
+		target   utils.TimeRange
+		expected []utils.TimeRange
+	}{
+		{
+			name:     "Completamente cubierto",
+			target:   utils.TimeRange{Start: at(15, 10, 0), End: at(15, 12, 0)},
+			expected: nil,
+		},
+		{
+			name:     "Antes de la apertura",
+			target:   utils.TimeRange{Start: at(15, 8, 0), End: at(15, 10, 0)},
+			expected: []utils.TimeRange{{Start: at(15, 8, 0), End: at(15, 9, 0)}},
+		},
+		{
+			name:     "Atraviesa la pausa del mediodía",
+			target:   utils.TimeRange{Start: at(15, 12, 0), End: at(15, 15, 0)},
+			expected: []utils.TimeRange{{Start: at(15, 13, 0), End: at(15, 14, 0)}},
+		},
+		{
+			name:   "Fuera de todo horario",
+			target: utils.TimeRange{Start: at(15, 19, 0), End: at(15, 21, 0)},
+			expected: []utils.TimeRange{
+				{Start: at(15, 19, 0), End: at(15, 21, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.SubtractRanges(tt.target, opening))
+		})
+	}
+
+	t.Run("Cruce de medianoche con horarios contiguos", func(t *testing.T) {
+		overnight := []utils.TimeRange{
+			{Start: at(15, 20, 0), End: at(16, 0, 0)},
+			{Start: at(16, 0, 0), End: at(16, 6, 0)},
+		}
+		target := utils.TimeRange{Start: at(15, 22, 0), End: at(16, 2, 0)}
+
+		assert.Empty(t, utils.SubtractRanges(target, overnight))
+	})
+}
+
+func TestPeakLoad(t *testing.T) {
+	window := utils.TimeRange{Start: at(15, 9, 0), End: at(15, 13, 0)}
+
+	loads := []utils.Load{
+		{TimeRange: utils.TimeRange{Start: at(15, 9, 0), End: at(15, 11, 0)}, Amount: 5},
+		{TimeRange: utils.TimeRange{Start: at(15, 10, 0), End: at(15, 12, 0)}, Amount: 3},
+		{TimeRange: utils.TimeRange{Start: at(15, 11, 0), End: at(15, 13, 0)}, Amount: 4},  // Empieza cuando termina la primera
+		{TimeRange: utils.TimeRange{Start: at(15, 14, 0), End: at(15, 15, 0)}, Amount: 20}, // Fuera de la ventana
+	}
+
+	assert.Equal(t, 8, utils.PeakLoad(loads, window), "Pico entre 10:00 y 11:00 (5 + 3)")
+	assert.Equal(t, 7, utils.PeakLoad(loads, utils.TimeRange{Start: at(15, 11, 0), End: at(15, 12, 0)}))
+	assert.Equal(t, 0, utils.PeakLoad(nil, window))
+}
+
+func TestOverloadedRanges(t *testing.T) {
+	loads := []utils.Load{
+		{TimeRange: utils.TimeRange{Start: at(15, 9, 0), End: at(15, 11, 0)}, Amount: 5},
+		{TimeRange: utils.TimeRange{Start: at(15, 10, 0), End: at(15, 12, 0)}, Amount: 3},
+	}
+
+	// Exclusivo: cualquier ocupación bloquea
+	assert.Equal(t, []utils.TimeRange{{Start: at(15, 9, 0), End: at(15, 12, 0)}}, utils.OverloadedRanges(loads, 0))
+
+	// Capacidad 10 y se piden 4 plazas: bloquea donde hay más de 6 ocupadas
+	assert.Equal(t, []utils.TimeRange{{Start: at(15, 10, 0), End: at(15, 11, 0)}}, utils.OverloadedRanges(loads, 6))
+
+	assert.Empty(t, utils.OverloadedRanges(loads, 8))
+}
+
+func TestAlignRange(t *testing.T) {
+	free := utils.TimeRange{Start: at(15, 10, 7), End: at(15, 11, 50)}
+
+	aligned, ok := utils.AlignRange(free, 30*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, utils.TimeRange{Start: at(15, 10, 30), End: at(15, 11, 30)}, aligned)
+
+	_, ok = utils.AlignRange(utils.TimeRange{Start: at(15, 10, 5), End: at(15, 10, 25)}, 30*time.Minute)
+	assert.False(t, ok, "No queda ningún tramo alineado")
+
+	unchanged, ok := utils.AlignRange(free, 0)
+	assert.True(t, ok)
+	assert.Equal(t, free, unchanged)
+}
+
+func TestShiftWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// La ocurrencia elegida pasa de las 10:00 a las 11:30 del mismo día
+	from := time.Date(2025, 3, 4, 10, 0, 0, 0, loc)
+	to := time.Date(2025, 3, 4, 11, 30, 0, 0, loc)
+
+	// Una ocurrencia posterior al cambio de horario (9 de marzo) conserva la hora de reloj
+	occurrence := time.Date(2025, 3, 11, 10, 0, 0, 0, loc)
+	shifted := utils.ShiftWallClock(occurrence.UTC(), from, to, loc)
+
+	assert.Equal(t, time.Date(2025, 3, 11, 11, 30, 0, 0, loc), shifted)
+	assert.Equal(t, 15, shifted.UTC().Hour(), "11:30 EDT son las 15:30 UTC")
+
+	// También se trasladan los cambios de día
+	nextDay := utils.ShiftWallClock(occurrence, from, from.AddDate(0, 0, 1), loc)
+	assert.Equal(t, time.Date(2025, 3, 12, 10, 0, 0, 0, loc), nextDay)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"Reservify/config"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// SignCheckInToken firma el código de check-in de una reserva. Incluye el inicio de la reserva,
+// así que un código deja de servir si la reserva cambia de horario.
+func SignCheckInToken(bookingID uint, start time.Time) string {
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.JWTSecret))
+	fmt.Fprintf(mac, "check-in:%d:%d", bookingID, start.Unix())
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CheckInURL arma el enlace que se codifica en el QR de check-in de una reserva
+func CheckInURL(bookingID uint, token string) string {
+	return fmt.Sprintf("%s/check-in?booking=%d&token=%s", config.AppConfig.FrontendURL, bookingID, token)
+}
+
+// VerifyCheckInToken comprueba que el código corresponda a la reserva y a su horario actual
+func VerifyCheckInToken(bookingID uint, start time.Time, token string) bool {
+	expected := SignCheckInToken(bookingID, start)
+	return hmac.Equal([]byte(expected), []byte(token))
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Frecuencias de recurrencia soportadas (subconjunto de RRULE)
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// MaxOccurrences limita cuántas ocurrencias puede generar una regla
+const MaxOccurrences = 366
+
+// RecurrenceRule representa una regla de repetición al estilo RRULE
+type RecurrenceRule struct {
+	Frequency string
+	Interval  int
+	ByDay     []time.Weekday
+	Count     int
+	Until     *time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ParseWeekdays convierte códigos RRULE ("MO", "TU", ...) en días de la semana
+func ParseWeekdays(codes []string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, code := range codes {
+		day, ok := weekdayCodes[strings.ToUpper(strings.TrimSpace(code))]
+		if !ok {
+			return nil, fmt.Errorf("día de la semana inválido: %s", code)
+		}
+		if !containsWeekday(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// FormatWeekdays convierte días de la semana a códigos RRULE separados por coma
+func FormatWeekdays(days []time.Weekday) string {
+	codes := make([]string, 0, len(days))
+	for _, day := range days {
+		for code, weekday := range weekdayCodes {
+			if weekday == day {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return strings.Join(codes, ",")
+}
+
+// Validate verifica que la regla sea coherente
+func (r RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return fmt.Errorf("frecuencia inválida: %s", r.Frequency)
+	}
+	if r.Interval < 1 {
+		return errors.New("el intervalo debe ser mayor o igual a 1")
+	}
+	if r.Count <= 0 && r.Until == nil {
+		return errors.New("la recurrencia debe indicar count o until")
+	}
+	if r.Count > MaxOccurrences {
+		return fmt.Errorf("la recurrencia no puede superar %d ocurrencias", MaxOccurrences)
+	}
+	if r.Frequency == FrequencyMonthly && len(r.ByDay) > 0 {
+		return errors.New("byday solo se admite con frecuencia daily o weekly")
+	}
+	return nil
+}
+
+// ExpandRecurrence genera los inicios de cada ocurrencia a partir de start (incluido si coincide con la regla)
+func ExpandRecurrence(start time.Time, rule RecurrenceRule) ([]time.Time, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	var occurrences []time.Time
+	done := func(candidate time.Time) bool {
+		if rule.Until != nil && candidate.After(*rule.Until) {
+			return true
+		}
+		return rule.Count > 0 && len(occurrences) >= rule.Count
+	}
+
+	// Cada iteración avanza un periodo; el límite evita bucles infinitos con until lejanos
+	for period := 0; period <= MaxOccurrences*7; period++ {
+		candidates := periodCandidates(start, rule, period)
+		if len(candidates) == 0 {
+			continue
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if done(candidate) {
+				return occurrences, nil
+			}
+			occurrences = append(occurrences, candidate)
+			if len(occurrences) > MaxOccurrences {
+				return nil, fmt.Errorf("la recurrencia no puede superar %d ocurrencias", MaxOccurrences)
+			}
+		}
+	}
+
+	return occurrences, nil
+}
+
+// periodCandidates devuelve las fechas candidatas del periodo n, conservando la hora local de start
+func periodCandidates(start time.Time, rule RecurrenceRule, n int) []time.Time {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	switch rule.Frequency {
+	case FrequencyDaily:
+		candidate := at(start.Year(), start.Month(), start.Day()+n*rule.Interval)
+		if len(rule.ByDay) > 0 && !containsWeekday(rule.ByDay, candidate.Weekday()) {
+			return nil
+		}
+		return []time.Time{candidate}
+
+	case FrequencyWeekly:
+		days := rule.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Semana que empieza el lunes, como en RRULE con WKST=MO
+		offset := (int(start.Weekday()) + 6) % 7
+		weekStart := start.Day() - offset + n*rule.Interval*7
+
+		var candidates []time.Time
+		for _, day := range days {
+			candidates = append(candidates, at(start.Year(), start.Month(), weekStart+(int(day)+6)%7))
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+		return candidates
+
+	case FrequencyMonthly:
+		candidate := at(start.Year(), start.Month()+time.Month(n*rule.Interval), start.Day())
+		// Los meses sin ese día (p. ej. 31) se omiten, igual que en RRULE
+		if candidate.Day() != start.Day() {
+			return nil
+		}
+		return []time.Time{candidate}
+	}
+
+	return nil
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
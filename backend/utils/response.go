@@ -33,3 +33,12 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 
 	c.JSON(statusCode, response)
 }
+
+// Envía una respuesta de error con datos estructurados (p. ej. la regla incumplida)
+func ErrorDataResponse(c *gin.Context, statusCode int, message string, data interface{}) {
+	c.JSON(statusCode, Response{
+		Success: false,
+		Message: message,
+		Data:    data,
+	})
+}
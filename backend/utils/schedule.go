@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeRange representa un intervalo de tiempo semiabierto [Start, End)
+type TimeRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ClockOn combina un día con una hora "HH:MM:SS" o "HH:MM" (se permite "24:00:00" como fin de día)
+func ClockOn(day time.Time, clock string) (time.Time, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return time.Time{}, fmt.Errorf("formato de hora inválido: %s", clock)
+	}
+
+	var values [3]int
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("formato de hora inválido: %s", clock)
+		}
+		values[i] = value
+	}
+
+	hour, minute, second := values[0], values[1], values[2]
+	if hour < 0 || minute < 0 || minute > 59 || second < 0 || second > 59 {
+		return time.Time{}, fmt.Errorf("formato de hora inválido: %s", clock)
+	}
+	if hour > 24 || (hour == 24 && (minute > 0 || second > 0)) {
+		return time.Time{}, errors.New("la hora no puede ser posterior a 24:00:00")
+	}
+
+	// time.Date normaliza 24:00:00 como la medianoche del día siguiente
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location()), nil
+}
+
+// MergeRanges ordena los intervalos y une los que se solapan o son contiguos
+func MergeRanges(ranges []TimeRange) []TimeRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := make([]TimeRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []TimeRange{sorted[0]}
+	for _, current := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !current.Start.After(last.End) {
+			// Solapado o contiguo: extender el último intervalo
+			if current.End.After(last.End) {
+				last.End = current.End
+			}
+			continue
+		}
+		merged = append(merged, current)
+	}
+
+	return merged
+}
+
+// SubtractRanges devuelve las partes de target que no están cubiertas por ningún intervalo de covered
+func SubtractRanges(target TimeRange, covered []TimeRange) []TimeRange {
+	var gaps []TimeRange
+	cursor := target.Start
+
+	for _, r := range MergeRanges(covered) {
+		if !r.End.After(cursor) {
+			continue
+		}
+		if !r.Start.Before(target.End) {
+			break
+		}
+		if r.Start.After(cursor) {
+			gaps = append(gaps, TimeRange{Start: cursor, End: r.Start})
+		}
+		cursor = r.End
+		if !cursor.Before(target.End) {
+			return gaps
+		}
+	}
+
+	if cursor.Before(target.End) {
+		gaps = append(gaps, TimeRange{Start: cursor, End: target.End})
+	}
+
+	return gaps
+}
+
+// FormatRanges describe una lista de intervalos en texto legible
+func FormatRanges(ranges []TimeRange) string {
+	parts := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		parts = append(parts, fmt.Sprintf("%s - %s", r.Start.Format("2006-01-02 15:04"), r.End.Format("2006-01-02 15:04")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Load representa una ocupación (p. ej. plazas reservadas) durante un intervalo
+type Load struct {
+	TimeRange
+	Amount int
+}
+
+// PeakLoad calcula la ocupación simultánea máxima dentro de window
+func PeakLoad(loads []Load, window TimeRange) int {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+
+	var events []event
+	for _, load := range loads {
+		start, end := load.Start, load.End
+		if start.Before(window.Start) {
+			start = window.Start
+		}
+		if end.After(window.End) {
+			end = window.End
+		}
+		if !start.Before(end) {
+			continue
+		}
+		events = append(events, event{at: start, delta: load.Amount}, event{at: end, delta: -load.Amount})
+	}
+
+	// Con intervalos semiabiertos, las salidas se procesan antes que las entradas del mismo instante
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].delta < events[j].delta
+		}
+		return events[i].at.Before(events[j].at)
+	})
+
+	current, peak := 0, 0
+	for _, e := range events {
+		current += e.delta
+		if current > peak {
+			peak = current
+		}
+	}
+
+	return peak
+}
+
+// OverloadedRanges devuelve los tramos en los que la ocupación total supera threshold
+func OverloadedRanges(loads []Load, threshold int) []TimeRange {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+
+	var events []event
+	for _, load := range loads {
+		if !load.Start.Before(load.End) {
+			continue
+		}
+		events = append(events, event{at: load.Start, delta: load.Amount}, event{at: load.End, delta: -load.Amount})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].delta < events[j].delta
+		}
+		return events[i].at.Before(events[j].at)
+	})
+
+	var ranges []TimeRange
+	current := 0
+	var openedAt *time.Time
+	for i := range events {
+		current += events[i].delta
+		if current > threshold && openedAt == nil {
+			openedAt = &events[i].at
+		} else if current <= threshold && openedAt != nil {
+			ranges = append(ranges, TimeRange{Start: *openedAt, End: events[i].at})
+			openedAt = nil
+		}
+	}
+
+	return MergeRanges(ranges)
+}
+
+// AlignRange recorta el intervalo a múltiplos de step contados desde la medianoche local
+func AlignRange(r TimeRange, step time.Duration) (TimeRange, bool) {
+	if step <= 0 {
+		return r, r.Start.Before(r.End)
+	}
+
+	startDay := time.Date(r.Start.Year(), r.Start.Month(), r.Start.Day(), 0, 0, 0, 0, r.Start.Location())
+	start := r.Start
+	if offset := r.Start.Sub(startDay) % step; offset != 0 {
+		start = r.Start.Add(step - offset)
+	}
+
+	endDay := time.Date(r.End.Year(), r.End.Month(), r.End.Day(), 0, 0, 0, 0, r.End.Location())
+	end := r.End.Add(-(r.End.Sub(endDay) % step))
+
+	aligned := TimeRange{Start: start, End: end}
+	return aligned, aligned.Start.Before(aligned.End)
+}
+
+// ShiftWallClock aplica a t el mismo desplazamiento de fecha y hora local que hay entre from y to,
+// de modo que un cambio de horario de verano no altera la hora de reloj
+func ShiftWallClock(t, from, to time.Time, loc *time.Location) time.Time {
+	t, from, to = t.In(loc), from.In(loc), to.In(loc)
+
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(toDay.Sub(fromDay).Hours() / 24)
+
+	clockOf := func(v time.Time) int { return v.Hour()*3600 + v.Minute()*60 + v.Second() }
+	seconds := clockOf(to) - clockOf(from)
+
+	return time.Date(t.Year(), t.Month(), t.Day()+days, t.Hour(), t.Minute(), t.Second()+seconds, t.Nanosecond(), loc)
+}